@@ -25,25 +25,30 @@ const (
 
 // SevenBridges is main entry point for communicating with SevenBridges API.
 type SevenBridges struct {
-	client   *gwc.Client
-	User     UserService
-	Project  ProjectService
-	Files    FileService
-	Download DownloadService
-	Upload   UploadService
+	client       *gwc.Client
+	User         UserService
+	Project      ProjectService
+	Files        FileService
+	Download     DownloadService
+	Upload       UploadService
+	Task         TaskService
+	App          AppService
+	Volume       VolumeService
+	Import       ImportService
+	Export       ExportService
+	Billing      BillingService
+	Invoice      InvoiceService
+	Division     DivisionService
+	Team         TeamService
+	Dataset      DatasetService
+	Notification NotificationService
+	Marker       MarkerService
 }
 
 // New returns new instance of SevenBridges that can be used to issue requests
 // to Seven Bridges API.
 func New(baseURL, token string) *SevenBridges {
-	client := gwc.New(
-		http.DefaultClient,
-		url.URL(baseURL),
-		tokenAuth(token),
-		headers.Set("User-Agent", userAgent),
-		errorHandler(),
-		errors.Errors(),
-	)
+	client := newClient(baseURL, token)
 
 	sb := &SevenBridges{
 		client: client,
@@ -53,9 +58,34 @@ func New(baseURL, token string) *SevenBridges {
 	sb.Files = newFileService(client)
 	sb.Download = newDownloadService(client)
 	sb.Upload = newUploadService(client)
+	sb.Task = newTaskService(client)
+	sb.App = newAppService(client)
+	sb.Volume = newVolumeService(client)
+	sb.Import = newImportService(client)
+	sb.Export = newExportService(client)
+	sb.Billing = newBillingService(client)
+	sb.Invoice = newInvoiceService(client)
+	sb.Division = newDivisionService(client)
+	sb.Team = newTeamService(client)
+	sb.Dataset = newDatasetService(client)
+	sb.Notification = newNotificationService(client)
+	sb.Marker = newMarkerService(client)
 	return sb
 }
 
+// newClient returns client that sends authenticated requests to API on
+// provided base URL and handles its errors.
+func newClient(baseURL, token string) *gwc.Client {
+	return gwc.New(
+		http.DefaultClient,
+		url.URL(baseURL),
+		tokenAuth(token),
+		headers.Set("User-Agent", userAgent),
+		errorHandler(),
+		errors.Errors(),
+	)
+}
+
 // service is thin wrapper around gwc.Layer with purpose of allowing group of
 // endpoints to share same middlewares and provide utility stuff commonly
 // needed by most of endpoints.
@@ -0,0 +1,159 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+)
+
+// AppSyncStatus holds information about copied app and revision of the app
+// it has been copied from.
+type AppSyncStatus struct {
+	// AppID is ID of copied app, without revision.
+	AppID string
+	// Revision is current revision of copied app.
+	Revision int
+	// Source is ID of app copied app has been created from, without revision.
+	Source string
+	// SourceRevision is revision of source app that copy is based on.
+	SourceRevision int
+	// LatestSourceRevision is latest revision of source app.
+	LatestSourceRevision int
+}
+
+// Outdated returns true if source app has newer revision than the one copy
+// is based on.
+func (s *AppSyncStatus) Outdated() bool {
+	return s.LatestSourceRevision > s.SourceRevision
+}
+
+// OutdatedTask holds task that was run on revision of an app that is not
+// the latest one, or task whose app could not be fetched.
+type OutdatedTask struct {
+	Task *Task
+	// AppID is ID of the app task was run on, without revision.
+	AppID string
+	// Revision is revision of the app task was run on.
+	Revision int
+	// LatestRevision is current latest revision of the app. It is
+	// LatestRevision constant if app could not be fetched.
+	LatestRevision int
+	// Err is error that occurred while fetching the app (e.g. app has been
+	// deleted), in which case it is not known if task is outdated.
+	Err error
+}
+
+// SplitAppID splits app ID in form of "owner/project/app/revision" to app ID
+// without revision and revision number. If revision is not part of provided
+// ID, LatestRevision is returned as revision.
+func SplitAppID(id string) (string, int) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 4 {
+		if revision, err := strconv.Atoi(parts[3]); err == nil {
+			return strings.Join(parts[:3], "/"), revision
+		}
+	}
+	return id, LatestRevision
+}
+
+func (as *appService) SyncStatus(ctx context.Context, appID string) (*AppSyncStatus, error) {
+	app, _, err := as.ByID(ctx, appID, LatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	var extensions struct {
+		CopyOf string `json:"sbg:copyOf"`
+	}
+	if len(app.Raw) > 0 {
+		if err := json.Unmarshal(app.Raw, &extensions); err != nil {
+			return nil, err
+		}
+	}
+	if extensions.CopyOf == "" {
+		return nil, ErrNotCopy
+	}
+
+	status := &AppSyncStatus{Revision: app.Revision}
+	status.AppID, _ = SplitAppID(app.ID)
+	status.Source, status.SourceRevision = SplitAppID(extensions.CopyOf)
+	source, _, err := as.ByID(ctx, status.Source, LatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	status.LatestSourceRevision = source.Revision
+	return status, nil
+}
+
+func (as *appService) Sync(ctx context.Context, appID string) (*App, *Response, error) {
+	a := new(App)
+	resp, err := as.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/"+appID+"/actions/sync"),
+		responsebody.JSON(a),
+	)
+	return a, resp, err
+}
+
+func (ts *taskService) Outdated(ctx context.Context, projectID string) ([]*OutdatedTask, error) {
+	latest := map[string]int{}
+	failed := map[string]error{}
+	var outdated []*OutdatedTask
+	opt := &TaskListOptions{Project: projectID}
+	for {
+		tasks, resp, err := ts.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			// draft tasks have not been run on any revision yet
+			if t.Status == TaskDraft {
+				continue
+			}
+			appID, revision := SplitAppID(t.App)
+			if revision == LatestRevision {
+				continue
+			}
+			latestRevision, ok := latest[appID]
+			if !ok && failed[appID] == nil {
+				app, _, err := ts.apps.ByID(ctx, appID, LatestRevision)
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				if err != nil {
+					failed[appID] = err
+				} else {
+					latestRevision = app.Revision
+					latest[appID] = latestRevision
+				}
+			}
+			if err := failed[appID]; err != nil {
+				outdated = append(outdated, &OutdatedTask{
+					Task:           t,
+					AppID:          appID,
+					Revision:       revision,
+					LatestRevision: LatestRevision,
+					Err:            err,
+				})
+				continue
+			}
+			if revision < latestRevision {
+				outdated = append(outdated, &OutdatedTask{
+					Task:           t,
+					AppID:          appID,
+					Revision:       revision,
+					LatestRevision: latestRevision,
+				})
+			}
+		}
+		if !resp.HasNextPage() {
+			return outdated, nil
+		}
+		opt.ListOptions = *resp.NextPage()
+	}
+}
@@ -0,0 +1,59 @@
+package sevenbridges_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+func TestOutdatedTasks(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		fetched = map[string]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/tasks":
+			w.Write([]byte(`{"items": [
+				{"id": "old", "status": "COMPLETED", "app": "user/project/tool/1"},
+				{"id": "current", "status": "FAILED", "app": "user/project/tool/3"},
+				{"id": "draft", "status": "DRAFT", "app": "user/project/tool/1"},
+				{"id": "deleted1", "status": "COMPLETED", "app": "user/project/deleted/1"},
+				{"id": "deleted2", "status": "ABORTED", "app": "user/project/deleted/2"}
+			]}`))
+		case "/apps/user/project/tool":
+			w.Write([]byte(`{"id": "user/project/tool/3", "revision": 3}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status": 404, "message": "Not found"}`))
+		}
+	}))
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	outdated, err := client.Task.Outdated(context.Background(), "user/project")
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if len(outdated) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(outdated))
+	}
+	if o := outdated[0]; o.Task.ID != "old" || o.Revision != 1 || o.LatestRevision != 3 || o.Err != nil {
+		t.Errorf("Unexpected outdated task: %+v", o)
+	}
+	for _, o := range outdated[1:] {
+		if o.AppID != "user/project/deleted" || o.Err == nil {
+			t.Errorf("Expected error for task %s of deleted app, got %+v", o.Task.ID, o)
+		}
+	}
+	if fetched["/apps/user/project/deleted"] != 1 {
+		t.Errorf("Expected deleted app to be fetched once, got %d", fetched["/apps/user/project/deleted"])
+	}
+}
@@ -0,0 +1,176 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	c "github.com/delicb/cliware"
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+const (
+	// LatestRevision can be used instead of revision number to access latest
+	// revision of an app.
+	LatestRevision = -1
+
+	// AppVisibilityPublic is visibility of publicly available apps.
+	AppVisibilityPublic = "public"
+)
+
+// ErrNotCopy is returned when sync status is requested for app that has not
+// been copied from another app.
+var ErrNotCopy = errors.New("sevenbridges: app is not a copy of another app")
+
+// App holds information about app (tool or workflow) on SevenBridges platform.
+type App struct {
+	Href     string `json:"href"`
+	ID       string `json:"id"`
+	Project  string `json:"project"`
+	Name     string `json:"name"`
+	Revision int    `json:"revision"`
+	// Raw is CWL description of the app.
+	Raw json.RawMessage `json:"raw,omitempty"`
+}
+
+// AppListOptions specifies optional filters for listing apps.
+type AppListOptions struct {
+	ListOptions
+	// Project is ID of project whose apps should be listed.
+	Project string `url:"project,omitempty"`
+	// Visibility should be set to AppVisibilityPublic to list public apps.
+	Visibility string `url:"visibility,omitempty"`
+	// Query is search term matched against app names and descriptions.
+	Query string `url:"q,omitempty"`
+}
+
+// AppCopy is structure that defines body required for copying app to
+// another project.
+type AppCopy struct {
+	// Project is ID of project to which app is copied.
+	Project string `json:"project"`
+	// Name is name of copied app. If not provided, original name is used.
+	Name string `json:"name,omitempty"`
+}
+
+// AppService is interface that defines app related operations available on
+// SevenBridges platform.
+type AppService interface {
+	// List returns apps (single page) that match provided options.
+	List(ctx context.Context, opt *AppListOptions) ([]*App, *Response, error)
+	// ByID returns app with provided ID and revision. LatestRevision can be
+	// used to get latest revision.
+	ByID(ctx context.Context, appID string, revision int) (*App, *Response, error)
+	// Raw returns raw CWL description of app with provided ID and revision.
+	Raw(ctx context.Context, appID string, revision int) (json.RawMessage, *Response, error)
+	// Install creates new app with provided ID (in form of
+	// "owner/project/app_name") from raw CWL description.
+	Install(ctx context.Context, appID string, raw json.RawMessage) (*App, *Response, error)
+	// CreateRevision creates new revision of existing app from raw CWL
+	// description. Revision has to be next revision number of the app.
+	CreateRevision(ctx context.Context, appID string, revision int, raw json.RawMessage) (*App, *Response, error)
+	// Copy copies app with provided ID to another project.
+	Copy(ctx context.Context, appID string, ac AppCopy) (*App, *Response, error)
+	// SyncStatus compares copied app with the app it has been copied from.
+	// ErrNotCopy is returned if app is not a copy.
+	SyncStatus(ctx context.Context, appID string) (*AppSyncStatus, error)
+	// Sync updates copied app to latest revision of the app it has been
+	// copied from.
+	Sync(ctx context.Context, appID string) (*App, *Response, error)
+}
+
+type appService struct {
+	*service
+}
+
+func newAppService(client gwc.Doer) AppService {
+	service := newService(client)
+	service.Use(url.AddPath("/apps"))
+	return &appService{service}
+}
+
+// just make sure at compile time that appService implements AppService
+var _ AppService = new(appService)
+
+// appPath returns middleware that adds path to app with provided ID and
+// revision to request.
+func appPath(appID string, revision int) c.Middleware {
+	path := "/" + appID
+	if revision != LatestRevision {
+		path += "/" + strconv.Itoa(revision)
+	}
+	return url.AddPath(path)
+}
+
+func (as *appService) List(ctx context.Context, opt *AppListOptions) ([]*App, *Response, error) {
+	var a []*App
+	resp, err := as.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&a),
+	)
+	return a, resp, err
+}
+
+func (as *appService) ByID(ctx context.Context, appID string, revision int) (*App, *Response, error) {
+	a := new(App)
+	resp, err := as.Do(
+		ctx,
+		headers.Method("GET"),
+		appPath(appID, revision),
+		responsebody.JSON(a),
+	)
+	return a, resp, err
+}
+
+func (as *appService) Raw(ctx context.Context, appID string, revision int) (json.RawMessage, *Response, error) {
+	var raw json.RawMessage
+	resp, err := as.Do(
+		ctx,
+		headers.Method("GET"),
+		appPath(appID, revision),
+		url.AddPath("/raw"),
+		responsebody.JSON(&raw),
+	)
+	return raw, resp, err
+}
+
+func (as *appService) Install(ctx context.Context, appID string, raw json.RawMessage) (*App, *Response, error) {
+	return as.install(ctx, appID, LatestRevision, raw)
+}
+
+func (as *appService) CreateRevision(ctx context.Context, appID string, revision int, raw json.RawMessage) (*App, *Response, error) {
+	return as.install(ctx, appID, revision, raw)
+}
+
+// install uploads raw CWL description as app with provided ID and revision.
+func (as *appService) install(ctx context.Context, appID string, revision int, raw json.RawMessage) (*App, *Response, error) {
+	a := new(App)
+	resp, err := as.Do(
+		ctx,
+		headers.Method("POST"),
+		appPath(appID, revision),
+		url.AddPath("/raw"),
+		body.JSON(raw),
+		responsebody.JSON(a),
+	)
+	return a, resp, err
+}
+
+func (as *appService) Copy(ctx context.Context, appID string, ac AppCopy) (*App, *Response, error) {
+	a := new(App)
+	resp, err := as.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/"+appID+"/actions/copy"),
+		body.JSON(ac),
+		responsebody.JSON(a),
+	)
+	return a, resp, err
+}
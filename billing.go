@@ -0,0 +1,167 @@
+package sevenbridges
+
+import (
+	"context"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// BillingGroup holds information about billing group that projects are
+// charged to.
+type BillingGroup struct {
+	Href     string `json:"href"`
+	ID       string `json:"id"`
+	Owner    string `json:"owner"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Pending  bool   `json:"pending"`
+	Disabled bool   `json:"disabled"`
+	Balance  *Money `json:"balance"`
+}
+
+// BreakdownOptions specifies optional filters for listing billing group
+// breakdowns. Only costs in provided date range are returned.
+type BreakdownOptions struct {
+	ListOptions
+	DateFrom time.Time `url:"date_from,omitempty" layout:"2006-01-02"`
+	DateTo   time.Time `url:"date_to,omitempty" layout:"2006-01-02"`
+	// InvoiceID limits breakdown to costs in invoice with provided ID.
+	InvoiceID string `url:"invoice_id,omitempty"`
+}
+
+// AnalysisCost holds cost of single analysis (task) charged to billing group.
+type AnalysisCost struct {
+	ProjectName    string    `json:"project_name"`
+	AnalysisType   string    `json:"analysis_type"`
+	AnalysisID     string    `json:"analysis_id"`
+	AnalysisName   string    `json:"analysis_name"`
+	AnalysisStatus string    `json:"analysis_status"`
+	RunnerUsername string    `json:"runner_username"`
+	TimeStarted    time.Time `json:"time_started"`
+	TimeFinished   time.Time `json:"time_finished"`
+	TaskDuration   string    `json:"task_duration"`
+	Cost           *Money    `json:"analysis_cost"`
+	Refunded       bool      `json:"refunded"`
+	RefundedAmount Amount    `json:"refunded_amount"`
+}
+
+// StorageUsage is size and cost of files in single storage class.
+type StorageUsage struct {
+	Size int64  `json:"size"`
+	Cost *Money `json:"storage_cost"`
+}
+
+// StorageCost holds storage cost of single project charged to billing group.
+type StorageCost struct {
+	ProjectName      string        `json:"project_name"`
+	ProjectCreatedBy string        `json:"project_created_by"`
+	Location         string        `json:"location"`
+	Active           *StorageUsage `json:"active"`
+	Archived         *StorageUsage `json:"archived"`
+	ProjectLocked    bool          `json:"project_locked"`
+}
+
+// EgressCost holds cost of data downloaded from single project charged to
+// billing group.
+type EgressCost struct {
+	ProjectName  string `json:"project_name"`
+	DownloadedBy string `json:"downloaded_by"`
+	Downloaded   int64  `json:"downloaded"`
+	Cost         *Money `json:"egress_cost"`
+}
+
+// BillingService is interface that defines billing related operations
+// available on SevenBridges platform.
+type BillingService interface {
+	// List returns billing groups (single page) current user has access to.
+	List(ctx context.Context, opt *ListOptions) ([]*BillingGroup, *Response, error)
+	// ByID returns billing group with provided ID.
+	ByID(ctx context.Context, groupID string) (*BillingGroup, *Response, error)
+	// Balance returns current balance of billing group with provided ID.
+	Balance(ctx context.Context, groupID string) (*Money, *Response, error)
+	// AnalysisBreakdown returns analysis costs (single page) of billing group
+	// with provided ID.
+	AnalysisBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*AnalysisCost, *Response, error)
+	// StorageBreakdown returns storage costs (single page) of billing group
+	// with provided ID.
+	StorageBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*StorageCost, *Response, error)
+	// EgressBreakdown returns egress costs (single page) of billing group
+	// with provided ID.
+	EgressBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*EgressCost, *Response, error)
+}
+
+type billingService struct {
+	*service
+}
+
+func newBillingService(client gwc.Doer) BillingService {
+	service := newService(client)
+	service.Use(url.AddPath("/billing/groups"))
+	return &billingService{service}
+}
+
+// just make sure at compile time that billingService implements BillingService
+var _ BillingService = new(billingService)
+
+func (bs *billingService) List(ctx context.Context, opt *ListOptions) ([]*BillingGroup, *Response, error) {
+	var g []*BillingGroup
+	resp, err := bs.Do(
+		ctx,
+		headers.Method("GET"),
+		listOptions(opt),
+		pageResponse(&g),
+	)
+	return g, resp, err
+}
+
+func (bs *billingService) ByID(ctx context.Context, groupID string) (*BillingGroup, *Response, error) {
+	g := new(BillingGroup)
+	resp, err := bs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+groupID),
+		responsebody.JSON(g),
+	)
+	return g, resp, err
+}
+
+func (bs *billingService) Balance(ctx context.Context, groupID string) (*Money, *Response, error) {
+	g, resp, err := bs.ByID(ctx, groupID)
+	if err != nil {
+		return nil, resp, err
+	}
+	return g.Balance, resp, nil
+}
+
+func (bs *billingService) AnalysisBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*AnalysisCost, *Response, error) {
+	var c []*AnalysisCost
+	resp, err := bs.breakdown(ctx, groupID, "analysis", opt, &c)
+	return c, resp, err
+}
+
+func (bs *billingService) StorageBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*StorageCost, *Response, error) {
+	var c []*StorageCost
+	resp, err := bs.breakdown(ctx, groupID, "storage", opt, &c)
+	return c, resp, err
+}
+
+func (bs *billingService) EgressBreakdown(ctx context.Context, groupID string, opt *BreakdownOptions) ([]*EgressCost, *Response, error) {
+	var c []*EgressCost
+	resp, err := bs.breakdown(ctx, groupID, "egress", opt, &c)
+	return c, resp, err
+}
+
+// breakdown fetches single page of breakdown of provided kind into data.
+func (bs *billingService) breakdown(ctx context.Context, groupID, kind string, opt *BreakdownOptions, data interface{}) (*Response, error) {
+	return bs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+groupID+"/breakdown/"+kind),
+		queryOptions(opt),
+		pageResponse(data),
+	)
+}
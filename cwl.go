@@ -0,0 +1,684 @@
+package sevenbridges
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// CWLClassCommandLineTool is class of CWL process that wraps single tool.
+	CWLClassCommandLineTool = "CommandLineTool"
+	// CWLClassWorkflow is class of CWL process composed of multiple steps.
+	CWLClassWorkflow = "Workflow"
+
+	// sbgPrefix is prefix of SevenBridges CWL extensions.
+	sbgPrefix = "sbg:"
+)
+
+// Process is implemented by all CWL process classes (CommandLineTool and
+// Workflow).
+type Process interface {
+	// Common returns fields shared by all CWL process classes.
+	Common() *CWLProcess
+}
+
+// CWLProcess holds fields shared by all CWL process classes.
+type CWLProcess struct {
+	Class        string          `json:"class"`
+	CWLVersion   string          `json:"cwlVersion,omitempty"`
+	ID           string          `json:"id,omitempty"`
+	Label        string          `json:"label,omitempty"`
+	Doc          string          `json:"doc,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Inputs       CWLParameters   `json:"inputs"`
+	Outputs      CWLParameters   `json:"outputs"`
+	Requirements CWLRequirements `json:"requirements,omitempty"`
+	Hints        CWLRequirements `json:"hints,omitempty"`
+	// SBG holds SevenBridges extensions of the process (e.g. "sbg:revision",
+	// "sbg:toolkit"), mapped by their full names. They are serialized back
+	// together with other fields of the process.
+	SBG map[string]json.RawMessage `json:"-"`
+}
+
+// Common returns p itself, so CWLProcess embedded in concrete classes
+// satisfies Process interface.
+func (p *CWLProcess) Common() *CWLProcess {
+	return p
+}
+
+// CommandLineTool is CWL process that wraps execution of single tool.
+type CommandLineTool struct {
+	CWLProcess
+	BaseCommand interface{}       `json:"baseCommand,omitempty"`
+	Arguments   []json.RawMessage `json:"arguments,omitempty"`
+	Stdin       string            `json:"stdin,omitempty"`
+	Stdout      string            `json:"stdout,omitempty"`
+	Stderr      string            `json:"stderr,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (t *CommandLineTool) MarshalJSON() ([]byte, error) {
+	type tool CommandLineTool
+	return withExtensions((*tool)(t), t.SBG)
+}
+
+// Workflow is CWL process that connects multiple steps.
+type Workflow struct {
+	CWLProcess
+	Steps WorkflowSteps `json:"steps"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (w *Workflow) MarshalJSON() ([]byte, error) {
+	type workflow Workflow
+	return withExtensions((*workflow)(w), w.SBG)
+}
+
+// WorkflowStep is single step of a workflow.
+type WorkflowStep struct {
+	ID      string      `json:"id"`
+	Label   string      `json:"label,omitempty"`
+	In      CWLEntries  `json:"in,omitempty"`
+	Inputs  CWLEntries  `json:"inputs,omitempty"`
+	Out     CWLEntries  `json:"out,omitempty"`
+	Outputs CWLEntries  `json:"outputs,omitempty"`
+	Scatter interface{} `json:"scatter,omitempty"`
+	// Run is CWL description of process executed by step.
+	Run json.RawMessage `json:"run"`
+	// SBG holds SevenBridges extensions of the step (e.g. "sbg:x", "sbg:y"),
+	// mapped by their full names.
+	SBG map[string]json.RawMessage `json:"-"`
+}
+
+// Process parses and returns process executed by workflow step.
+func (ws *WorkflowStep) Process() (Process, error) {
+	return ParseCWL(ws.Run)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (ws *WorkflowStep) UnmarshalJSON(b []byte) error {
+	type step WorkflowStep
+	if err := json.Unmarshal(b, (*step)(ws)); err != nil {
+		return err
+	}
+	sbg, err := sbgExtensions(b)
+	ws.SBG = sbg
+	return err
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (ws *WorkflowStep) MarshalJSON() ([]byte, error) {
+	type step WorkflowStep
+	return withExtensions((*step)(ws), ws.SBG)
+}
+
+// WorkflowSteps is list of workflow steps. When decoded, it accepts both list
+// form and map form (keyed by step ID).
+type WorkflowSteps []*WorkflowStep
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (ws *WorkflowSteps) UnmarshalJSON(b []byte) error {
+	if !isJSONObject(b) {
+		var steps []*WorkflowStep
+		err := json.Unmarshal(b, &steps)
+		*ws = steps
+		return err
+	}
+
+	var byID map[string]*WorkflowStep
+	if err := json.Unmarshal(b, &byID); err != nil {
+		return err
+	}
+	*ws = make(WorkflowSteps, 0, len(byID))
+	for id, step := range byID {
+		if step == nil {
+			step = new(WorkflowStep)
+		}
+		if step.ID == "" {
+			step.ID = id
+		}
+		*ws = append(*ws, step)
+	}
+	// map form has no order, so keep steps ordered by ID
+	sort.Slice(*ws, func(i, j int) bool { return (*ws)[i].ID < (*ws)[j].ID })
+	return nil
+}
+
+// CWLEntries is list of raw CWL objects, like inputs and outputs of workflow
+// step. When decoded, it accepts both list form and map form (keyed by ID),
+// which is converted to list of objects with "id" field. Value in map form
+// that is not an object is shorthand for its "source".
+type CWLEntries []json.RawMessage
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (es *CWLEntries) UnmarshalJSON(b []byte) error {
+	if !isJSONObject(b) {
+		var entries []json.RawMessage
+		err := json.Unmarshal(b, &entries)
+		*es = entries
+		return err
+	}
+
+	var byID map[string]json.RawMessage
+	if err := json.Unmarshal(b, &byID); err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	*es = make(CWLEntries, 0, len(byID))
+	for _, id := range ids {
+		fields := map[string]json.RawMessage{}
+		if raw := byID[id]; isJSONObject(raw) {
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return err
+			}
+		} else {
+			fields["source"] = raw
+		}
+		if _, ok := fields["id"]; !ok {
+			fields["id"], _ = json.Marshal(id)
+		}
+		entry, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		*es = append(*es, entry)
+	}
+	return nil
+}
+
+// CWLRequirement is requirement or hint of a CWL process.
+type CWLRequirement struct {
+	Class string
+	// Fields holds all fields of requirement except class.
+	Fields map[string]json.RawMessage
+}
+
+// CWLRequirements is list of process requirements or hints. When decoded, it
+// accepts both list form and map form (keyed by class).
+type CWLRequirements []*CWLRequirement
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (rs *CWLRequirements) UnmarshalJSON(b []byte) error {
+	var raw []map[string]json.RawMessage
+	if isJSONObject(b) {
+		var byClass map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(b, &byClass); err != nil {
+			return err
+		}
+		for class, fields := range byClass {
+			fields["class"], _ = json.Marshal(class)
+			raw = append(raw, fields)
+		}
+		sort.Slice(raw, func(i, j int) bool { return string(raw[i]["class"]) < string(raw[j]["class"]) })
+	} else if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*rs = make(CWLRequirements, 0, len(raw))
+	for _, fields := range raw {
+		r := &CWLRequirement{Fields: fields}
+		if class, ok := fields["class"]; ok {
+			if err := json.Unmarshal(class, &r.Class); err != nil {
+				return err
+			}
+			delete(fields, "class")
+		}
+		*rs = append(*rs, r)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (r *CWLRequirement) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage, len(r.Fields)+1)
+	for k, v := range r.Fields {
+		fields[k] = v
+	}
+	fields["class"], _ = json.Marshal(r.Class)
+	return json.Marshal(fields)
+}
+
+// CWLParameter is input or output parameter of a CWL process.
+type CWLParameter struct {
+	// ID is identifier of the parameter without leading "#" and process
+	// name, so it can be used as key in task inputs.
+	ID             string          `json:"id"`
+	Label          string          `json:"label,omitempty"`
+	Doc            string          `json:"doc,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Type           *CWLType        `json:"type"`
+	Default        interface{}     `json:"default,omitempty"`
+	Format         interface{}     `json:"format,omitempty"`
+	SecondaryFiles interface{}     `json:"secondaryFiles,omitempty"`
+	InputBinding   json.RawMessage `json:"inputBinding,omitempty"`
+	OutputBinding  json.RawMessage `json:"outputBinding,omitempty"`
+	// SBG holds SevenBridges extensions of the parameter (e.g. "sbg:fileTypes",
+	// "sbg:toolDefaultValue"), mapped by their full names.
+	SBG map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (p *CWLParameter) UnmarshalJSON(b []byte) error {
+	type parameter CWLParameter
+	if err := json.Unmarshal(b, (*parameter)(p)); err != nil {
+		return err
+	}
+	p.ID = normalizeCWLID(p.ID)
+	sbg, err := sbgExtensions(b)
+	p.SBG = sbg
+	return err
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (p *CWLParameter) MarshalJSON() ([]byte, error) {
+	type parameter CWLParameter
+	return withExtensions((*parameter)(p), p.SBG)
+}
+
+// CWLParameters is list of process parameters. When decoded, it accepts both
+// list form and map form (keyed by parameter ID).
+type CWLParameters []*CWLParameter
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (ps *CWLParameters) UnmarshalJSON(b []byte) error {
+	if !isJSONObject(b) {
+		var params []*CWLParameter
+		err := json.Unmarshal(b, &params)
+		*ps = params
+		return err
+	}
+
+	var byID map[string]json.RawMessage
+	if err := json.Unmarshal(b, &byID); err != nil {
+		return err
+	}
+	*ps = make(CWLParameters, 0, len(byID))
+	for id, raw := range byID {
+		p := new(CWLParameter)
+		if isJSONObject(raw) {
+			if err := json.Unmarshal(raw, p); err != nil {
+				return err
+			}
+		} else {
+			// short form, only type of parameter is provided
+			p.Type = new(CWLType)
+			if err := json.Unmarshal(raw, p.Type); err != nil {
+				return err
+			}
+		}
+		if p.ID == "" {
+			p.ID = normalizeCWLID(id)
+		}
+		*ps = append(*ps, p)
+	}
+	// map form has no order, so keep parameters ordered by ID
+	sort.Slice(*ps, func(i, j int) bool { return (*ps)[i].ID < (*ps)[j].ID })
+	return nil
+}
+
+// ByID returns parameter with provided ID or nil if there is no such
+// parameter.
+func (ps CWLParameters) ByID(id string) *CWLParameter {
+	for _, p := range ps {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// Names of CWL types.
+const (
+	CWLNull      = "null"
+	CWLBoolean   = "boolean"
+	CWLInt       = "int"
+	CWLLong      = "long"
+	CWLFloat     = "float"
+	CWLDouble    = "double"
+	CWLString    = "string"
+	CWLFile      = "File"
+	CWLDirectory = "Directory"
+	CWLArray     = "array"
+	CWLEnum      = "enum"
+	CWLRecord    = "record"
+	CWLUnion     = "union"
+)
+
+// CWLType is type of CWL parameter. CWL type shorthands ("File?", "File[]")
+// and unions with "null" are resolved to Optional flag and array types.
+type CWLType struct {
+	// Type is name of the type (one of CWL type names, e.g. CWLFile).
+	Type string
+	// Optional is flag marking that parameter of this type accepts null.
+	Optional bool
+	// Items is type of array items, set for arrays.
+	Items *CWLType
+	// Symbols holds allowed values, set for enums.
+	Symbols []string
+	// Fields holds fields of records.
+	Fields []*CWLParameter
+	// Union holds alternatives, set for unions of multiple non-null types.
+	Union []*CWLType
+	// Name is name of enum or record type.
+	Name string
+}
+
+// IsFile returns true if type is single file.
+func (t *CWLType) IsFile() bool {
+	return t.Type == CWLFile
+}
+
+// IsArray returns true if type is array.
+func (t *CWLType) IsArray() bool {
+	return t.Type == CWLArray
+}
+
+// IsFileArray returns true if type is array of files.
+func (t *CWLType) IsFileArray() bool {
+	return t.IsArray() && t.Items != nil && t.Items.IsFile()
+}
+
+// String returns type in CWL shorthand notation (e.g. "File[]?").
+func (t *CWLType) String() string {
+	var s string
+	switch t.Type {
+	case CWLArray:
+		items := "Any"
+		if t.Items != nil {
+			items = t.Items.String()
+		}
+		s = items + "[]"
+	case CWLUnion:
+		alternatives := make([]string, 0, len(t.Union))
+		for _, u := range t.Union {
+			alternatives = append(alternatives, u.String())
+		}
+		s = "(" + strings.Join(alternatives, "|") + ")"
+	default:
+		s = t.Type
+	}
+	if t.Optional {
+		s += "?"
+	}
+	return s
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (t *CWLType) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*t = *parseCWLTypeName(s)
+	case len(b) > 0 && b[0] == '[':
+		var alternatives []*CWLType
+		if err := json.Unmarshal(b, &alternatives); err != nil {
+			return err
+		}
+		var nonNull []*CWLType
+		optional := false
+		for _, a := range alternatives {
+			if a.Type == CWLNull {
+				optional = true
+			} else {
+				nonNull = append(nonNull, a)
+			}
+		}
+		switch len(nonNull) {
+		case 0:
+			*t = CWLType{Type: CWLNull}
+		case 1:
+			*t = *nonNull[0]
+		default:
+			*t = CWLType{Type: CWLUnion, Union: nonNull}
+		}
+		t.Optional = t.Optional || optional
+	case isJSONObject(b):
+		var complex struct {
+			Type    string          `json:"type"`
+			Items   *CWLType        `json:"items"`
+			Symbols []string        `json:"symbols"`
+			Fields  json.RawMessage `json:"fields"`
+			Name    string          `json:"name"`
+		}
+		if err := json.Unmarshal(b, &complex); err != nil {
+			return err
+		}
+		*t = CWLType{
+			Type:    complex.Type,
+			Items:   complex.Items,
+			Symbols: complex.Symbols,
+			Name:    complex.Name,
+		}
+		for i, s := range t.Symbols {
+			// symbols might be fully qualified (e.g. "#input/value")
+			t.Symbols[i] = s[strings.LastIndex(s, "/")+1:]
+		}
+		if len(complex.Fields) > 0 {
+			var fields CWLParameters
+			if err := json.Unmarshal(normalizeRecordFields(complex.Fields), &fields); err != nil {
+				return err
+			}
+			t.Fields = fields
+		}
+	default:
+		return fmt.Errorf("sevenbridges: unsupported CWL type %s", b)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (t *CWLType) MarshalJSON() ([]byte, error) {
+	var v interface{}
+	switch t.Type {
+	case CWLArray:
+		v = map[string]interface{}{"type": CWLArray, "items": t.Items}
+	case CWLEnum:
+		v = map[string]interface{}{"type": CWLEnum, "symbols": t.Symbols, "name": t.Name}
+	case CWLRecord:
+		v = map[string]interface{}{"type": CWLRecord, "fields": t.Fields, "name": t.Name}
+	case CWLUnion:
+		v = t.Union
+	default:
+		v = t.Type
+	}
+	if !t.Optional {
+		return json.Marshal(v)
+	}
+	if t.Type == CWLUnion {
+		return json.Marshal(append([]interface{}{CWLNull}, toInterfaces(t.Union)...))
+	}
+	return json.Marshal([]interface{}{CWLNull, v})
+}
+
+// ParseCWL parses raw CWL description and returns CommandLineTool or
+// Workflow, depending on its class.
+func ParseCWL(raw json.RawMessage) (Process, error) {
+	var header struct {
+		Class string `json:"class"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+
+	var p Process
+	switch header.Class {
+	case CWLClassCommandLineTool:
+		p = new(CommandLineTool)
+	case CWLClassWorkflow:
+		p = new(Workflow)
+	default:
+		return nil, fmt.Errorf("sevenbridges: unsupported CWL class %q", header.Class)
+	}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return nil, err
+	}
+	sbg, err := sbgExtensions(raw)
+	p.Common().SBG = sbg
+	return p, err
+}
+
+// CWL parses raw CWL description of the app. Raw description is available
+// only on apps fetched by ID.
+func (a *App) CWL() (Process, error) {
+	if len(a.Raw) == 0 {
+		return nil, fmt.Errorf("sevenbridges: app %s has no CWL description", a.ID)
+	}
+	return ParseCWL(a.Raw)
+}
+
+// InputInfo describes single input of an app.
+type InputInfo struct {
+	ID    string
+	Label string
+	Type  *CWLType
+	// Required is flag marking that input has to be provided in order to
+	// run the app. Inputs that are optional or have default are not required.
+	Required bool
+	Default  interface{}
+	// File is flag marking that input accepts single file.
+	File bool
+	// Array is flag marking that input accepts list of values.
+	Array bool
+	// FileTypes holds expected file extensions, as defined by "sbg:fileTypes".
+	FileTypes []string
+}
+
+// InputInfo returns description of all inputs of the process.
+func (p *CWLProcess) InputInfo() []*InputInfo {
+	infos := make([]*InputInfo, 0, len(p.Inputs))
+	for _, in := range p.Inputs {
+		info := &InputInfo{
+			ID:      in.ID,
+			Label:   in.Label,
+			Type:    in.Type,
+			Default: in.Default,
+		}
+		if in.Type != nil {
+			info.Required = !in.Type.Optional && in.Default == nil
+			info.File = in.Type.IsFile()
+			info.Array = in.Type.IsArray()
+		}
+		if raw, ok := in.SBG["sbg:fileTypes"]; ok {
+			var fileTypes string
+			if json.Unmarshal(raw, &fileTypes) == nil {
+				for _, ft := range strings.Split(fileTypes, ",") {
+					info.FileTypes = append(info.FileTypes, strings.TrimSpace(ft))
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// RequiredInputs returns description of inputs that has to be provided to
+// run the process.
+func (p *CWLProcess) RequiredInputs() []*InputInfo {
+	var required []*InputInfo
+	for _, info := range p.InputInfo() {
+		if info.Required {
+			required = append(required, info)
+		}
+	}
+	return required
+}
+
+// parseCWLTypeName parses type provided as string, including "?" and "[]"
+// shorthands.
+func parseCWLTypeName(s string) *CWLType {
+	t := new(CWLType)
+	if strings.HasSuffix(s, "?") {
+		t.Optional = true
+		s = strings.TrimSuffix(s, "?")
+	}
+	if strings.HasSuffix(s, "[]") {
+		t.Type = CWLArray
+		t.Items = parseCWLTypeName(strings.TrimSuffix(s, "[]"))
+		return t
+	}
+	t.Type = s
+	return t
+}
+
+// normalizeCWLID strips leading "#" and process prefix from CWL identifier.
+func normalizeCWLID(id string) string {
+	id = strings.TrimPrefix(id, "#")
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+// normalizeRecordFields converts "name" of record fields to "id", so they
+// can be decoded as regular parameters.
+func normalizeRecordFields(raw json.RawMessage) json.RawMessage {
+	var fields []map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil {
+		return raw
+	}
+	for _, f := range fields {
+		if _, ok := f["id"]; !ok {
+			f["id"] = f["name"]
+		}
+	}
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+	return normalized
+}
+
+// sbgExtensions returns all top level fields with "sbg:" prefix from
+// provided JSON object.
+func sbgExtensions(b []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	sbg := map[string]json.RawMessage{}
+	for k, v := range fields {
+		if strings.HasPrefix(k, sbgPrefix) {
+			sbg[k] = v
+		}
+	}
+	return sbg, nil
+}
+
+// withExtensions serializes provided value to JSON object and adds provided
+// SevenBridges extensions to it.
+func withExtensions(v interface{}, sbg map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(sbg) == 0 {
+		return b, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range sbg {
+		fields[k] = v
+	}
+	return json.Marshal(fields)
+}
+
+// isJSONObject returns true if provided JSON value is an object.
+func isJSONObject(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '{'
+}
+
+func toInterfaces(types []*CWLType) []interface{} {
+	res := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		res = append(res, t)
+	}
+	return res
+}
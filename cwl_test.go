@@ -0,0 +1,150 @@
+package sevenbridges_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+const testTool = `{
+	"class": "CommandLineTool",
+	"cwlVersion": "v1.0",
+	"id": "user/project/aligner/0",
+	"sbg:revision": 3,
+	"baseCommand": ["bwa", "mem"],
+	"requirements": [{"class": "DockerRequirement", "dockerPull": "images.sbgenomics.com/bwa"}],
+	"hints": {"sbg:AWSInstanceType": {"value": "c4.2xlarge"}},
+	"inputs": [
+		{"id": "#reads", "type": {"type": "array", "items": "File"}, "sbg:fileTypes": "FASTQ, FQ"},
+		{"id": "#reference", "type": "File"},
+		{"id": "#threads", "type": ["null", "int"]},
+		{"id": "#prefix", "type": "string", "default": "out"},
+		{"id": "#mode", "type": {"type": "enum", "name": "mode", "symbols": ["#mode/fast", "#mode/slow"]}}
+	],
+	"outputs": {"aligned": "File?"}
+}`
+
+func TestParseCWL(t *testing.T) {
+	p, err := sevenbridges.ParseCWL(json.RawMessage(testTool))
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	tool, ok := p.(*sevenbridges.CommandLineTool)
+	if !ok {
+		t.Fatalf("Expected CommandLineTool, got %T", p)
+	}
+	if string(tool.SBG["sbg:revision"]) != "3" {
+		t.Errorf("Expected revision 3, got %s", tool.SBG["sbg:revision"])
+	}
+	if len(tool.Requirements) != 1 || tool.Requirements[0].Class != "DockerRequirement" {
+		t.Errorf("Requirements not parsed: %v", tool.Requirements)
+	}
+	if len(tool.Hints) != 1 || tool.Hints[0].Class != "sbg:AWSInstanceType" {
+		t.Errorf("Hints not parsed: %v", tool.Hints)
+	}
+	if out := tool.Outputs.ByID("aligned"); out == nil || out.Type.String() != "File?" {
+		t.Errorf("Output not parsed: %v", out)
+	}
+
+	for _, tc := range []struct {
+		id       string
+		typ      string
+		required bool
+		file     bool
+		array    bool
+	}{
+		{"reads", "File[]", true, false, true},
+		{"reference", "File", true, true, false},
+		{"threads", "int?", false, false, false},
+		{"prefix", "string", false, false, false},
+		{"mode", "enum", true, false, false},
+	} {
+		var info *sevenbridges.InputInfo
+		for _, i := range tool.InputInfo() {
+			if i.ID == tc.id {
+				info = i
+			}
+		}
+		if info == nil {
+			t.Errorf("Input %s not found", tc.id)
+			continue
+		}
+		if info.Type.String() != tc.typ || info.Required != tc.required || info.File != tc.file || info.Array != tc.array {
+			t.Errorf("Wrong info for %s: %s %+v", tc.id, info.Type, info)
+		}
+	}
+	if mode := tool.Inputs.ByID("mode"); len(mode.Type.Symbols) != 2 || mode.Type.Symbols[0] != "fast" {
+		t.Errorf("Enum symbols not parsed: %v", mode.Type.Symbols)
+	}
+	if reads := tool.Inputs.ByID("reads"); !reads.Type.IsFileArray() {
+		t.Errorf("Expected file array, got %s", reads.Type)
+	}
+	if len(tool.RequiredInputs()) != 3 {
+		t.Errorf("Expected 3 required inputs, got %d", len(tool.RequiredInputs()))
+	}
+}
+
+const testWorkflow = `{
+	"class": "Workflow",
+	"cwlVersion": "v1.0",
+	"sbg:revision": 2,
+	"inputs": {"reads": {"type": "File[]", "sbg:fileTypes": "FASTQ"}},
+	"outputs": [],
+	"steps": {
+		"sort": {
+			"in": {"input": "align/aligned"},
+			"out": ["sorted"],
+			"run": {"class": "CommandLineTool", "inputs": [], "outputs": []},
+			"sbg:x": 200
+		},
+		"align": {
+			"in": {"reads": {"source": "reads"}},
+			"out": ["aligned"],
+			"run": {"class": "CommandLineTool", "inputs": [], "outputs": []},
+			"sbg:x": 100
+		}
+	}
+}`
+
+func TestParseCWLWorkflow(t *testing.T) {
+	p, err := sevenbridges.ParseCWL(json.RawMessage(testWorkflow))
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	// parsed workflow has to survive serialization, e.g. for new revision
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	p, err = sevenbridges.ParseCWL(raw)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	wf, ok := p.(*sevenbridges.Workflow)
+	if !ok {
+		t.Fatalf("Expected Workflow, got %T", p)
+	}
+	if string(wf.SBG["sbg:revision"]) != "2" {
+		t.Errorf("Expected revision 2, got %s", wf.SBG["sbg:revision"])
+	}
+	if reads := wf.Inputs.ByID("reads"); reads == nil || string(reads.SBG["sbg:fileTypes"]) != `"FASTQ"` {
+		t.Errorf("Input extensions not kept: %v", reads)
+	}
+	if len(wf.Steps) != 2 || wf.Steps[0].ID != "align" || wf.Steps[1].ID != "sort" {
+		t.Fatalf("Steps not parsed: %v", wf.Steps)
+	}
+	if x := string(wf.Steps[0].SBG["sbg:x"]); x != "100" {
+		t.Errorf("Step extensions not kept: %s", x)
+	}
+	var in struct {
+		ID     string `json:"id"`
+		Source string `json:"source"`
+	}
+	if len(wf.Steps[1].In) != 1 || json.Unmarshal(wf.Steps[1].In[0], &in) != nil || in.ID != "input" || in.Source != "align/aligned" {
+		t.Errorf("Step inputs not parsed: %s", wf.Steps[1].In)
+	}
+	if _, err := wf.Steps[0].Process(); err != nil {
+		t.Errorf("Step process not parsed: %s", err)
+	}
+}
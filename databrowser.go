@@ -0,0 +1,198 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// Entities that can be queried through Data Browser.
+const (
+	EntityFiles    = "files"
+	EntityCases    = "cases"
+	EntitySamples  = "samples"
+	EntityPortions = "portions"
+	EntityAnalytes = "analytes"
+	EntityAliquots = "aliquots"
+)
+
+// dataBrowserPageSize is number of entities fetched per request when all
+// results of a query are needed.
+const dataBrowserPageSize = 100
+
+// DataBrowserQuery is query for entities (cases, samples, files...) in
+// Data Browser dataset. Query consists of entity, filters on properties of
+// that entity and relationships to other entities, which are queries
+// themselves. For example, open access files of breast cancer cases:
+//
+//	cases := NewDataBrowserQuery(EntityCases).Where("diseaseType", "Breast Invasive Carcinoma")
+//	files := NewDataBrowserQuery(EntityFiles).Where("accessLevel", "Open").Related(cases)
+type DataBrowserQuery struct {
+	entity        string
+	filters       map[string]interface{}
+	relationships map[string]*DataBrowserQuery
+}
+
+// NewDataBrowserQuery returns query for provided entity, without any filters.
+func NewDataBrowserQuery(entity string) *DataBrowserQuery {
+	return &DataBrowserQuery{
+		entity:        entity,
+		filters:       make(map[string]interface{}),
+		relationships: make(map[string]*DataBrowserQuery),
+	}
+}
+
+// Entity returns entity this query returns.
+func (q *DataBrowserQuery) Entity() string {
+	return q.entity
+}
+
+// Where limits result to entities whose property has one of provided values.
+// Property is name used by Data Browser, e.g. "diseaseType" or
+// "hasDiseaseType".
+func (q *DataBrowserQuery) Where(property string, values ...interface{}) *DataBrowserQuery {
+	if len(values) == 1 {
+		q.filters[hasKey(property)] = values[0]
+	} else {
+		q.filters[hasKey(property)] = values
+	}
+	return q
+}
+
+// Related limits result to entities related to entities matching provided
+// query.
+func (q *DataBrowserQuery) Related(related *DataBrowserQuery) *DataBrowserQuery {
+	q.relationships[hasKey(strings.TrimSuffix(related.entity, "s"))] = related
+	return q
+}
+
+// hasKey converts property or entity name to key used in query body, e.g.
+// "diseaseType" to "hasDiseaseType".
+func hasKey(name string) string {
+	if strings.HasPrefix(name, "has") {
+		return name
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return "has" + string(unicode.ToUpper(r)) + name[size:]
+}
+
+// conditions returns filters and relationships of query as map.
+func (q *DataBrowserQuery) conditions() map[string]interface{} {
+	data := make(map[string]interface{}, len(q.filters)+len(q.relationships))
+	for k, v := range q.filters {
+		data[k] = v
+	}
+	for k, related := range q.relationships {
+		data[k] = related.conditions()
+	}
+	return data
+}
+
+// MarshalJSON serializes query to body expected by Data Browser.
+func (q *DataBrowserQuery) MarshalJSON() ([]byte, error) {
+	data := q.conditions()
+	data["entity"] = q.entity
+	return json.Marshal(data)
+}
+
+// DataBrowserEntity is single entity returned by Data Browser query.
+type DataBrowserEntity struct {
+	Href  string `json:"href"`
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// DataBrowserService is interface that defines operations of Data Browser
+// (Datasets API), used for finding cases, samples and files in datasets
+// like TCGA.
+type DataBrowserService interface {
+	// Query returns entities (single page) of dataset with provided name
+	// (e.g. "tcga") that match provided query.
+	Query(ctx context.Context, dataset string, q *DataBrowserQuery, opt *ListOptions) ([]*DataBrowserEntity, *Response, error)
+	// Count returns number of entities of dataset that match provided query.
+	Count(ctx context.Context, dataset string, q *DataBrowserQuery) (int, *Response, error)
+	// FileIDs returns IDs of all files of dataset related to entities that
+	// match provided query. IDs can be used to copy files or as task inputs.
+	FileIDs(ctx context.Context, dataset string, q *DataBrowserQuery) ([]string, error)
+}
+
+type dataBrowserService struct {
+	*service
+}
+
+// NewDataBrowser returns client for Data Browser (Datasets API) on provided
+// base URL, e.g. "https://cgc-datasets-api.sbgenomics.com". Datasets API is
+// not served on the same URL as the rest of API, so it is not part of
+// SevenBridges.
+func NewDataBrowser(baseURL, token string) DataBrowserService {
+	return newDataBrowserService(newClient(baseURL, token))
+}
+
+func newDataBrowserService(client gwc.Doer) DataBrowserService {
+	service := newService(client)
+	service.Use(url.AddPath("/datasets"))
+	return &dataBrowserService{service}
+}
+
+// just make sure at compile time that dataBrowserService implements DataBrowserService
+var _ DataBrowserService = new(dataBrowserService)
+
+func (ds *dataBrowserService) Query(ctx context.Context, dataset string, q *DataBrowserQuery, opt *ListOptions) ([]*DataBrowserEntity, *Response, error) {
+	var result struct {
+		Embedded map[string][]*DataBrowserEntity `json:"_embedded"`
+	}
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/"+dataset+"/v0/query"),
+		listOptions(opt),
+		body.JSON(q),
+		responsebody.JSON(&result),
+	)
+	return result.Embedded[q.entity], resp, err
+}
+
+func (ds *dataBrowserService) Count(ctx context.Context, dataset string, q *DataBrowserQuery) (int, *Response, error) {
+	var result struct {
+		Total int `json:"total"`
+	}
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/"+dataset+"/v0/query/total"),
+		body.JSON(q),
+		responsebody.JSON(&result),
+	)
+	return result.Total, resp, err
+}
+
+func (ds *dataBrowserService) FileIDs(ctx context.Context, dataset string, q *DataBrowserQuery) ([]string, error) {
+	if q.entity != EntityFiles {
+		q = NewDataBrowserQuery(EntityFiles).Related(q)
+	}
+	var ids []string
+	opt := &ListOptions{Limit: dataBrowserPageSize}
+	for {
+		files, _, err := ds.Query(ctx, dataset, q, opt)
+		if err != nil {
+			return nil, err
+		}
+		// server may return less than requested even when there are more
+		// results, so only empty page means that all results are fetched
+		if len(files) == 0 {
+			return ids, nil
+		}
+		for _, f := range files {
+			ids = append(ids, f.ID)
+		}
+		opt.Offset += len(files)
+	}
+}
@@ -0,0 +1,78 @@
+package sevenbridges_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+func TestDataBrowserFileIDs(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/datasets/tcga/v0/query" {
+			http.NotFound(w, r)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		var body map[string]interface{}
+		json.Unmarshal(data, &body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		// 150 files in total, served in pages of at most 50 files
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit > 50 {
+			limit = 50
+		}
+		var files []map[string]string
+		for i := offset; i < offset+limit && i < 150; i++ {
+			files = append(files, map[string]string{"id": fmt.Sprintf("file%d", i)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"_embedded": map[string]interface{}{"files": files},
+		})
+	}))
+	defer server.Close()
+
+	browser := sevenbridges.NewDataBrowser(server.URL, "token")
+	cases := sevenbridges.NewDataBrowserQuery(sevenbridges.EntityCases).
+		Where("diseaseType", "Breast Invasive Carcinoma").
+		Where("gender", "FEMALE", "MALE")
+	ids, err := browser.FileIDs(context.Background(), "tcga", cases)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if len(ids) != 150 || ids[0] != "file0" || ids[149] != "file149" {
+		t.Errorf("Unexpected IDs: %v", ids)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// three full pages and empty one
+	if len(bodies) != 4 {
+		t.Fatalf("Expected 4 requests, got %d", len(bodies))
+	}
+	expected := map[string]interface{}{
+		"entity": "files",
+		"hasCase": map[string]interface{}{
+			"hasDiseaseType": "Breast Invasive Carcinoma",
+			"hasGender":      []interface{}{"FEMALE", "MALE"},
+		},
+	}
+	if !reflect.DeepEqual(bodies[0], expected) {
+		t.Errorf("Unexpected query: %v", bodies[0])
+	}
+}
@@ -0,0 +1,129 @@
+package sevenbridges
+
+import (
+	"context"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// DatasetVisibilityPublic is visibility of publicly available datasets.
+const DatasetVisibilityPublic = "public"
+
+// Dataset holds information about dataset, collection of files (e.g. public
+// reference data) that can be browsed and copied to projects.
+type Dataset struct {
+	Href        string `json:"href"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DatasetListOptions specifies optional filters for listing datasets.
+type DatasetListOptions struct {
+	ListOptions
+	// Visibility should be set to DatasetVisibilityPublic to list public
+	// datasets.
+	Visibility string `url:"visibility,omitempty"`
+}
+
+// DatasetMember holds information about member of a dataset and its
+// permissions.
+type DatasetMember struct {
+	Href        string              `json:"href"`
+	Username    string              `json:"username"`
+	Permissions *DatasetPermissions `json:"permissions"`
+}
+
+// DatasetPermissions holds set of permissions of a single member on dataset.
+type DatasetPermissions struct {
+	Write *bool `json:"write"`
+}
+
+// DatasetService is interface that defines dataset related operations
+// available on SevenBridges platform.
+type DatasetService interface {
+	// List returns datasets (single page) that match provided options.
+	List(ctx context.Context, opt *DatasetListOptions) ([]*Dataset, *Response, error)
+	// ListForUser returns datasets (single page) owned by user with provided
+	// username.
+	ListForUser(ctx context.Context, username string, opt *ListOptions) ([]*Dataset, *Response, error)
+	// ByID returns dataset with provided ID.
+	ByID(ctx context.Context, datasetID string) (*Dataset, *Response, error)
+	// Members returns members (single page) of dataset with provided ID.
+	Members(ctx context.Context, datasetID string, opt *ListOptions) ([]*DatasetMember, *Response, error)
+	// Files returns files (single page) in dataset with provided ID that
+	// match provided options, including metadata filters.
+	Files(ctx context.Context, datasetID string, opt *FileListOptions) ([]*File, *Response, error)
+}
+
+type datasetService struct {
+	*service
+	files FileService
+}
+
+func newDatasetService(client gwc.Doer) DatasetService {
+	service := newService(client)
+	service.Use(url.AddPath("/datasets"))
+	return &datasetService{service, newFileService(client)}
+}
+
+// just make sure at compile time that datasetService implements DatasetService
+var _ DatasetService = new(datasetService)
+
+func (ds *datasetService) List(ctx context.Context, opt *DatasetListOptions) ([]*Dataset, *Response, error) {
+	var d []*Dataset
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&d),
+	)
+	return d, resp, err
+}
+
+func (ds *datasetService) ListForUser(ctx context.Context, username string, opt *ListOptions) ([]*Dataset, *Response, error) {
+	var d []*Dataset
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+username),
+		listOptions(opt),
+		pageResponse(&d),
+	)
+	return d, resp, err
+}
+
+func (ds *datasetService) ByID(ctx context.Context, datasetID string) (*Dataset, *Response, error) {
+	d := new(Dataset)
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+datasetID),
+		responsebody.JSON(d),
+	)
+	return d, resp, err
+}
+
+func (ds *datasetService) Members(ctx context.Context, datasetID string, opt *ListOptions) ([]*DatasetMember, *Response, error) {
+	var m []*DatasetMember
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+datasetID+"/members"),
+		listOptions(opt),
+		pageResponse(&m),
+	)
+	return m, resp, err
+}
+
+func (ds *datasetService) Files(ctx context.Context, datasetID string, opt *FileListOptions) ([]*File, *Response, error) {
+	filter := FileListOptions{}
+	if opt != nil {
+		filter = *opt
+	}
+	filter.Dataset = datasetID
+	return ds.files.Query(ctx, &filter)
+}
@@ -0,0 +1,93 @@
+package sevenbridges
+
+import (
+	"context"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/query"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+const (
+	// DivisionRoleAdmin is role of division administrator.
+	DivisionRoleAdmin = "ADMIN"
+	// DivisionRoleMember is role of regular division member.
+	DivisionRoleMember = "MEMBER"
+	// DivisionRoleExternalCollaborator is role of user from outside of
+	// enterprise that has access to division.
+	DivisionRoleExternalCollaborator = "EXTERNAL_COLLABORATOR"
+)
+
+// Division holds information about division of enterprise account.
+type Division struct {
+	Href string `json:"href"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DivisionMember is user that is member of a division, with role that user
+// has in division.
+type DivisionMember struct {
+	User
+	Role string `json:"role"`
+}
+
+// DivisionService is interface that defines division related operations
+// available on SevenBridges platform.
+type DivisionService interface {
+	// List returns divisions (single page) current user is member of.
+	List(ctx context.Context, opt *ListOptions) ([]*Division, *Response, error)
+	// ByID returns division with provided ID.
+	ByID(ctx context.Context, divisionID string) (*Division, *Response, error)
+	// Members returns members (single page) of division with provided ID.
+	Members(ctx context.Context, divisionID string, opt *ListOptions) ([]*DivisionMember, *Response, error)
+}
+
+type divisionService struct {
+	*service
+}
+
+func newDivisionService(client gwc.Doer) DivisionService {
+	return &divisionService{newService(client)}
+}
+
+// just make sure at compile time that divisionService implements DivisionService
+var _ DivisionService = new(divisionService)
+
+func (ds *divisionService) List(ctx context.Context, opt *ListOptions) ([]*Division, *Response, error) {
+	var d []*Division
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/divisions"),
+		listOptions(opt),
+		pageResponse(&d),
+	)
+	return d, resp, err
+}
+
+func (ds *divisionService) ByID(ctx context.Context, divisionID string) (*Division, *Response, error) {
+	d := new(Division)
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/divisions/"+divisionID),
+		responsebody.JSON(d),
+	)
+	return d, resp, err
+}
+
+func (ds *divisionService) Members(ctx context.Context, divisionID string, opt *ListOptions) ([]*DivisionMember, *Response, error) {
+	var m []*DivisionMember
+	resp, err := ds.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/users"),
+		query.Add("division", divisionID),
+		listOptions(opt),
+		pageResponse(&m),
+	)
+	return m, resp, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"os"
@@ -40,6 +41,9 @@ type DownloadInfo struct {
 type DownloadService interface {
 	Info(ctx context.Context, fileID string) (*DownloadInfo, *Response, error)
 	Download(ctx context.Context, fileID, destination string) error
+	// DownloadWriter downloads whole file with provided ID in single request
+	// and writes its content to w. It is meant for small files, like logs.
+	DownloadWriter(ctx context.Context, fileID string, w io.Writer) error
 }
 
 type downloadService struct {
@@ -128,6 +132,20 @@ func (d *downloadService) Download(ctx context.Context, fileID, dst string) erro
 	return nil
 }
 
+func (d *downloadService) DownloadWriter(ctx context.Context, fileID string, w io.Writer) error {
+	info, _, err := d.Info(ctx, fileID)
+	if err != nil {
+		return err
+	}
+	_, err = d.Do(
+		ctx,
+		headers.Method("GET"),
+		curl.URL(info.URL),
+		responsebody.Writer(w),
+	)
+	return err
+}
+
 func (d *downloadService) downloadChunk(ctx context.Context, dst, url string, chunks <-chan chunk, report chan<- chunk, wg *sync.WaitGroup) {
 
 	for chunk := range chunks {
@@ -0,0 +1,230 @@
+package sevenbridges
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	c "github.com/delicb/cliware"
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/query"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// ErrMixedCopyOnly is returned when exports started in single bulk request
+// do not have the same CopyOnly flag.
+var ErrMixedCopyOnly = errors.New("sevenbridges: all exports in bulk request must have the same CopyOnly flag")
+
+// Export holds information about job that exports file from SevenBridges
+// platform to volume.
+type Export struct {
+	Href        string                 `json:"href"`
+	ID          string                 `json:"id"`
+	State       TransferState          `json:"state"`
+	Source      *ExportSource          `json:"source"`
+	Destination *ExportDestination     `json:"destination"`
+	Overwrite   bool                   `json:"overwrite"`
+	Properties  map[string]interface{} `json:"properties"`
+	StartedOn   time.Time              `json:"started_on"`
+	FinishedOn  time.Time              `json:"finished_on"`
+	// Result is exported file, available when export is completed.
+	Result *File `json:"result"`
+	// Error describes why export failed.
+	Error *ErrorInfo `json:"error"`
+}
+
+// ExportSource is file on SevenBridges platform that is exported.
+type ExportSource struct {
+	File string `json:"file"`
+}
+
+// ExportDestination is location on volume file is exported to.
+type ExportDestination struct {
+	Volume   string `json:"volume"`
+	Location string `json:"location"`
+}
+
+// ExportCreate is structure that defines body required for starting new
+// export.
+type ExportCreate struct {
+	Source      ExportSource      `json:"source"`
+	Destination ExportDestination `json:"destination"`
+	// Overwrite is flag marking if existing object on volume should be
+	// overwritten.
+	Overwrite bool `json:"overwrite,omitempty"`
+	// Properties holds service specific properties of exported object
+	// (e.g. "sse_algorithm" for S3).
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	// CopyOnly is flag marking that file should be copied to volume instead
+	// of moved. Moved files remain accessible on platform through volume.
+	CopyOnly bool `json:"-"`
+}
+
+// ExportListOptions specifies optional filters for listing exports.
+type ExportListOptions struct {
+	ListOptions
+	Volume string        `url:"volume,omitempty"`
+	State  TransferState `url:"state,omitempty"`
+}
+
+// BulkExportResult holds result of single export in bulk request. Exactly
+// one of Resource and Error is populated.
+type BulkExportResult struct {
+	Resource *Export    `json:"resource"`
+	Error    *ErrorInfo `json:"error"`
+}
+
+// ExportWaitResult holds outcome of waiting for single export.
+type ExportWaitResult struct {
+	ID string
+	// Export is last fetched state of export, nil if it was never fetched.
+	// Failed exports have their error in Export.Error.
+	Export *Export
+	// Err is set if export could not be fetched, e.g. because it does not
+	// exist. It is ErrTransferNotReturned if server left export out of
+	// response.
+	Err error
+}
+
+// ExportService is interface that defines export related operations available
+// on SevenBridges platform.
+type ExportService interface {
+	// List returns exports (single page) that match provided options.
+	List(ctx context.Context, opt *ExportListOptions) ([]*Export, *Response, error)
+	// ByID returns export with provided ID.
+	ByID(ctx context.Context, exportID string) (*Export, *Response, error)
+	// Create starts new export.
+	Create(ctx context.Context, ec ExportCreate) (*Export, *Response, error)
+	// BulkCreate starts multiple exports in single request. Results are in
+	// the same order as provided exports. At most 100 exports can be
+	// started at once. CopyOnly applies to whole request, so
+	// ErrMixedCopyOnly is returned if exports do not have the same value.
+	BulkCreate(ctx context.Context, ecs []ExportCreate) ([]*BulkExportResult, *Response, error)
+	// BulkGet returns exports with provided IDs in single request. At most
+	// 100 exports can be fetched at once.
+	BulkGet(ctx context.Context, exportIDs []string) ([]*BulkExportResult, *Response, error)
+	// Wait blocks until all exports with provided IDs are finished and returns
+	// their results in the same order. Any number of IDs can be provided.
+	// Exports that could not be fetched are not waited for and have error in
+	// their results. Error is returned only if waiting stopped before all
+	// exports finished, e.g. because context is done, in which case results
+	// hold last fetched state of every export.
+	Wait(ctx context.Context, exportIDs ...string) ([]*ExportWaitResult, error)
+}
+
+type exportService struct {
+	*service
+	bulk *service
+}
+
+func newExportService(client gwc.Doer) ExportService {
+	service := newService(client)
+	service.Use(url.AddPath("/storage/exports"))
+	bulk := newService(client)
+	bulk.Use(url.AddPath("/bulk/storage/exports"))
+	return &exportService{service, bulk}
+}
+
+// just make sure at compile time that exportService implements ExportService
+var _ ExportService = new(exportService)
+
+func (es *exportService) List(ctx context.Context, opt *ExportListOptions) ([]*Export, *Response, error) {
+	var e []*Export
+	resp, err := es.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&e),
+	)
+	return e, resp, err
+}
+
+func (es *exportService) ByID(ctx context.Context, exportID string) (*Export, *Response, error) {
+	e := new(Export)
+	resp, err := es.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+exportID),
+		responsebody.JSON(e),
+	)
+	return e, resp, err
+}
+
+func (es *exportService) Create(ctx context.Context, ec ExportCreate) (*Export, *Response, error) {
+	e := new(Export)
+	middlewares := []c.Middleware{
+		headers.Method("POST"),
+		body.JSON(ec),
+		responsebody.JSON(e),
+	}
+	if ec.CopyOnly {
+		middlewares = append(middlewares, query.Add("copy_only", "true"))
+	}
+	resp, err := es.Do(ctx, middlewares...)
+	return e, resp, err
+}
+
+func (es *exportService) BulkCreate(ctx context.Context, ecs []ExportCreate) ([]*BulkExportResult, *Response, error) {
+	copyOnly := len(ecs) > 0 && ecs[0].CopyOnly
+	for _, ec := range ecs {
+		if ec.CopyOnly != copyOnly {
+			return nil, nil, ErrMixedCopyOnly
+		}
+	}
+	var r []*BulkExportResult
+	middlewares := []c.Middleware{
+		headers.Method("POST"),
+		url.AddPath("/create"),
+		body.JSON(map[string][]ExportCreate{"items": ecs}),
+		pageResponse(&r),
+	}
+	if copyOnly {
+		middlewares = append(middlewares, query.Add("copy_only", "true"))
+	}
+	resp, err := es.bulk.Do(ctx, middlewares...)
+	return r, resp, err
+}
+
+func (es *exportService) BulkGet(ctx context.Context, exportIDs []string) ([]*BulkExportResult, *Response, error) {
+	var r []*BulkExportResult
+	resp, err := es.bulk.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/get"),
+		body.JSON(map[string][]string{"export_ids": exportIDs}),
+		pageResponse(&r),
+	)
+	return r, resp, err
+}
+
+func (es *exportService) Wait(ctx context.Context, exportIDs ...string) ([]*ExportWaitResult, error) {
+	exports := make(map[string]*Export, len(exportIDs))
+	errs, err := waitForTransfers(ctx, exportIDs, func(ctx context.Context, ids []string) (map[string]*transferStatus, *Response, error) {
+		results, resp, err := es.BulkGet(ctx, ids)
+		if err != nil {
+			return nil, resp, err
+		}
+		statuses := make(map[string]*transferStatus, len(results))
+		for i, r := range results {
+			switch {
+			case r == nil:
+			case r.Resource != nil:
+				exports[r.Resource.ID] = r.Resource
+				statuses[r.Resource.ID] = &transferStatus{State: r.Resource.State}
+			case r.Error != nil && len(results) == len(ids):
+				// errors do not hold ID of export, so they can be matched
+				// only by position in complete response
+				statuses[ids[i]] = &transferStatus{Err: r.Error}
+			}
+		}
+		return statuses, resp, nil
+	})
+	result := make([]*ExportWaitResult, 0, len(exportIDs))
+	for _, id := range exportIDs {
+		result = append(result, &ExportWaitResult{ID: id, Export: exports[id], Err: errs[id]})
+	}
+	return result, err
+}
@@ -2,8 +2,11 @@ package sevenbridges
 
 import (
 	"context"
+	"net/http"
 	"time"
 
+	c "github.com/delicb/cliware"
+	"github.com/delicb/cliware-middlewares/body"
 	"github.com/delicb/cliware-middlewares/headers"
 	"github.com/delicb/cliware-middlewares/query"
 	"github.com/delicb/cliware-middlewares/responsebody"
@@ -11,6 +14,13 @@ import (
 	"github.com/delicb/gwc"
 )
 
+const (
+	// FileTypeFile is type of regular file on SevenBridges platform.
+	FileTypeFile = "file"
+	// FileTypeFolder is type of folder on SevenBridges platform.
+	FileTypeFolder = "folder"
+)
+
 // Metadata is custom data attached to file.
 type Metadata map[string]interface{}
 
@@ -21,10 +31,49 @@ type File struct {
 	Name       string      `json:"name"`
 	Size       int64       `json:"size"`
 	Project    string      `json:"project"`
+	Parent     string      `json:"parent"`
+	Type       string      `json:"type"`
 	CreatedOn  time.Time   `json:"created_on"`
 	ModifiedOn time.Time   `json:"modified_on"`
 	Origin     interface{} `json:"origin"` // TODO: what is this?
 	Metadata   Metadata    `json:"metadata"`
+	Tags       []string    `json:"tags"`
+}
+
+// IsFolder returns true if f is folder and not regular file.
+func (f *File) IsFolder() bool {
+	return f.Type == FileTypeFolder
+}
+
+// FileListOptions specifies optional filters for querying files. Only one of
+// Project and Parent should be provided - Project lists root of the project,
+// Parent lists content of a folder.
+type FileListOptions struct {
+	ListOptions
+	Project string   `url:"project,omitempty"`
+	Parent  string   `url:"parent,omitempty"`
+	Dataset string   `url:"dataset,omitempty"`
+	Name    string   `url:"name,omitempty"`
+	Tags    []string `url:"tag,omitempty"`
+	// Metadata limits result to files whose metadata field (key) has one of
+	// provided values.
+	Metadata map[string][]string `url:"-"`
+}
+
+// FileCopy is structure that defines body required for copying file to
+// project.
+type FileCopy struct {
+	Project string `json:"project"`
+	// Name is name of copied file. If not provided, original name is used.
+	Name string `json:"name,omitempty"`
+}
+
+// FolderCreate is structure that defines body required for creating new
+// folder. Only one of Project and Parent should be provided.
+type FolderCreate struct {
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+	Parent  string `json:"parent,omitempty"`
 }
 
 // FileService is interface that defines operations on files on SevenBridges platform.
@@ -35,6 +84,16 @@ type FileService interface {
 	ByID(ctx context.Context, fileID string) (*File, *Response, error)
 	// Delete removes file with provided ID from platform.
 	Delete(ctx context.Context, fileID string) (*Response, error)
+	// Query returns files and folders (single page) matching provided options.
+	Query(ctx context.Context, opt *FileListOptions) ([]*File, *Response, error)
+	// CreateFolder creates new folder in project root or in another folder.
+	CreateFolder(ctx context.Context, fc FolderCreate) (*File, *Response, error)
+	// SetMetadata replaces metadata of file with provided ID.
+	SetMetadata(ctx context.Context, fileID string, metadata Metadata) (Metadata, *Response, error)
+	// SetTags replaces tags of file with provided ID.
+	SetTags(ctx context.Context, fileID string, tags []string) ([]string, *Response, error)
+	// Copy copies file with provided ID to another project.
+	Copy(ctx context.Context, fileID string, fc FileCopy) (*File, *Response, error)
 }
 
 type fileService struct {
@@ -45,6 +104,9 @@ func newFileService(client gwc.Doer) FileService {
 	return &fileService{newService(client)}
 }
 
+// just make sure at compile time that fileService implements FileService
+var _ FileService = new(fileService)
+
 func (fs *fileService) List(ctx context.Context, projectID string) ([]*File, *Response, error) {
 	var files []*File
 	resp, err := fs.Do(
@@ -75,3 +137,86 @@ func (fs *fileService) Delete(ctx context.Context, fileID string) (*Response, er
 		url.AddPath("/files/"+fileID),
 	)
 }
+
+func (fs *fileService) Query(ctx context.Context, opt *FileListOptions) ([]*File, *Response, error) {
+	var files []*File
+	resp, err := fs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/files"),
+		queryOptions(opt),
+		metadataFilters(opt),
+		pageResponse(&files),
+	)
+	return files, resp, err
+}
+
+// metadataFilters returns middleware that adds metadata filters from provided
+// options to request as "metadata.<field>" query parameters.
+func metadataFilters(opt *FileListOptions) c.Middleware {
+	return c.RequestProcessor(func(req *http.Request) error {
+		if opt == nil || len(opt.Metadata) == 0 {
+			return nil
+		}
+		q := req.URL.Query()
+		for field, values := range opt.Metadata {
+			q["metadata."+field] = values
+		}
+		req.URL.RawQuery = q.Encode()
+		return nil
+	})
+}
+
+func (fs *fileService) CreateFolder(ctx context.Context, fc FolderCreate) (*File, *Response, error) {
+	f := new(File)
+	resp, err := fs.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/files"),
+		body.JSON(struct {
+			FolderCreate
+			Type string `json:"type"`
+		}{fc, FileTypeFolder}),
+		responsebody.JSON(f),
+	)
+	return f, resp, err
+}
+
+func (fs *fileService) SetMetadata(ctx context.Context, fileID string, metadata Metadata) (Metadata, *Response, error) {
+	m := Metadata{}
+	resp, err := fs.Do(
+		ctx,
+		headers.Method("PUT"),
+		url.AddPath("/files/:fileID/metadata"),
+		url.Param("fileID", fileID),
+		body.JSON(metadata),
+		responsebody.JSON(&m),
+	)
+	return m, resp, err
+}
+
+func (fs *fileService) SetTags(ctx context.Context, fileID string, tags []string) ([]string, *Response, error) {
+	var t []string
+	resp, err := fs.Do(
+		ctx,
+		headers.Method("PUT"),
+		url.AddPath("/files/:fileID/tags"),
+		url.Param("fileID", fileID),
+		body.JSON(tags),
+		responsebody.JSON(&t),
+	)
+	return t, resp, err
+}
+
+func (fs *fileService) Copy(ctx context.Context, fileID string, fc FileCopy) (*File, *Response, error) {
+	f := new(File)
+	resp, err := fs.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/files/:fileID/actions/copy"),
+		url.Param("fileID", fileID),
+		body.JSON(fc),
+		responsebody.JSON(f),
+	)
+	return f, resp, err
+}
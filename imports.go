@@ -0,0 +1,217 @@
+package sevenbridges
+
+import (
+	"context"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// Import holds information about job that imports object from volume to
+// project on SevenBridges platform.
+type Import struct {
+	Href                    string             `json:"href"`
+	ID                      string             `json:"id"`
+	State                   TransferState      `json:"state"`
+	Source                  *ImportSource      `json:"source"`
+	Destination             *ImportDestination `json:"destination"`
+	Overwrite               bool               `json:"overwrite"`
+	Autorename              bool               `json:"autorename"`
+	PreserveFolderStructure bool               `json:"preserve_folder_structure"`
+	StartedOn               time.Time          `json:"started_on"`
+	FinishedOn              time.Time          `json:"finished_on"`
+	// Result is imported file, available when import is completed.
+	Result *File `json:"result"`
+	// Error describes why import failed.
+	Error *ErrorInfo `json:"error"`
+}
+
+// ImportSource is location of imported object on volume.
+type ImportSource struct {
+	Volume   string `json:"volume"`
+	Location string `json:"location"`
+}
+
+// ImportDestination is location on SevenBridges platform object is imported
+// to. Only one of Project and Parent should be provided.
+type ImportDestination struct {
+	Project string `json:"project,omitempty"`
+	// Parent is ID of folder object is imported to.
+	Parent string `json:"parent,omitempty"`
+	// Name is name of imported file. If not provided, name of object is used.
+	Name string `json:"name,omitempty"`
+}
+
+// ImportCreate is structure that defines body required for starting new
+// import.
+type ImportCreate struct {
+	Source      ImportSource      `json:"source"`
+	Destination ImportDestination `json:"destination"`
+	// Overwrite is flag marking if existing file with same name should be
+	// overwritten.
+	Overwrite bool `json:"overwrite,omitempty"`
+	// Autorename is flag marking if imported file should be renamed if file
+	// with same name already exists.
+	Autorename bool `json:"autorename,omitempty"`
+	// PreserveFolderStructure is flag marking if folder structure should be
+	// kept when importing folders.
+	PreserveFolderStructure *bool `json:"preserve_folder_structure,omitempty"`
+}
+
+// ImportListOptions specifies optional filters for listing imports.
+type ImportListOptions struct {
+	ListOptions
+	Volume  string        `url:"volume,omitempty"`
+	Project string        `url:"project,omitempty"`
+	State   TransferState `url:"state,omitempty"`
+}
+
+// BulkImportResult holds result of single import in bulk request. Exactly
+// one of Resource and Error is populated.
+type BulkImportResult struct {
+	Resource *Import    `json:"resource"`
+	Error    *ErrorInfo `json:"error"`
+}
+
+// ImportWaitResult holds outcome of waiting for single import.
+type ImportWaitResult struct {
+	ID string
+	// Import is last fetched state of import, nil if it was never fetched.
+	// Failed imports have their error in Import.Error.
+	Import *Import
+	// Err is set if import could not be fetched, e.g. because it does not
+	// exist. It is ErrTransferNotReturned if server left import out of
+	// response.
+	Err error
+}
+
+// ImportService is interface that defines import related operations available
+// on SevenBridges platform.
+type ImportService interface {
+	// List returns imports (single page) that match provided options.
+	List(ctx context.Context, opt *ImportListOptions) ([]*Import, *Response, error)
+	// ByID returns import with provided ID.
+	ByID(ctx context.Context, importID string) (*Import, *Response, error)
+	// Create starts new import.
+	Create(ctx context.Context, ic ImportCreate) (*Import, *Response, error)
+	// BulkCreate starts multiple imports in single request. Results are in
+	// the same order as provided imports. At most 100 imports can be
+	// started at once.
+	BulkCreate(ctx context.Context, ics []ImportCreate) ([]*BulkImportResult, *Response, error)
+	// BulkGet returns imports with provided IDs in single request. At most
+	// 100 imports can be fetched at once.
+	BulkGet(ctx context.Context, importIDs []string) ([]*BulkImportResult, *Response, error)
+	// Wait blocks until all imports with provided IDs are finished and returns
+	// their results in the same order. Any number of IDs can be provided.
+	// Imports that could not be fetched are not waited for and have error in
+	// their results. Error is returned only if waiting stopped before all
+	// imports finished, e.g. because context is done, in which case results
+	// hold last fetched state of every import.
+	Wait(ctx context.Context, importIDs ...string) ([]*ImportWaitResult, error)
+}
+
+type importService struct {
+	*service
+	bulk *service
+}
+
+func newImportService(client gwc.Doer) ImportService {
+	service := newService(client)
+	service.Use(url.AddPath("/storage/imports"))
+	bulk := newService(client)
+	bulk.Use(url.AddPath("/bulk/storage/imports"))
+	return &importService{service, bulk}
+}
+
+// just make sure at compile time that importService implements ImportService
+var _ ImportService = new(importService)
+
+func (is *importService) List(ctx context.Context, opt *ImportListOptions) ([]*Import, *Response, error) {
+	var i []*Import
+	resp, err := is.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&i),
+	)
+	return i, resp, err
+}
+
+func (is *importService) ByID(ctx context.Context, importID string) (*Import, *Response, error) {
+	i := new(Import)
+	resp, err := is.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+importID),
+		responsebody.JSON(i),
+	)
+	return i, resp, err
+}
+
+func (is *importService) Create(ctx context.Context, ic ImportCreate) (*Import, *Response, error) {
+	i := new(Import)
+	resp, err := is.Do(
+		ctx,
+		headers.Method("POST"),
+		body.JSON(ic),
+		responsebody.JSON(i),
+	)
+	return i, resp, err
+}
+
+func (is *importService) BulkCreate(ctx context.Context, ics []ImportCreate) ([]*BulkImportResult, *Response, error) {
+	var r []*BulkImportResult
+	resp, err := is.bulk.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/create"),
+		body.JSON(map[string][]ImportCreate{"items": ics}),
+		pageResponse(&r),
+	)
+	return r, resp, err
+}
+
+func (is *importService) BulkGet(ctx context.Context, importIDs []string) ([]*BulkImportResult, *Response, error) {
+	var r []*BulkImportResult
+	resp, err := is.bulk.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/get"),
+		body.JSON(map[string][]string{"import_ids": importIDs}),
+		pageResponse(&r),
+	)
+	return r, resp, err
+}
+
+func (is *importService) Wait(ctx context.Context, importIDs ...string) ([]*ImportWaitResult, error) {
+	imports := make(map[string]*Import, len(importIDs))
+	errs, err := waitForTransfers(ctx, importIDs, func(ctx context.Context, ids []string) (map[string]*transferStatus, *Response, error) {
+		results, resp, err := is.BulkGet(ctx, ids)
+		if err != nil {
+			return nil, resp, err
+		}
+		statuses := make(map[string]*transferStatus, len(results))
+		for i, r := range results {
+			switch {
+			case r == nil:
+			case r.Resource != nil:
+				imports[r.Resource.ID] = r.Resource
+				statuses[r.Resource.ID] = &transferStatus{State: r.Resource.State}
+			case r.Error != nil && len(results) == len(ids):
+				// errors do not hold ID of import, so they can be matched
+				// only by position in complete response
+				statuses[ids[i]] = &transferStatus{Err: r.Error}
+			}
+		}
+		return statuses, resp, nil
+	})
+	result := make([]*ImportWaitResult, 0, len(importIDs))
+	for _, id := range importIDs {
+		result = append(result, &ImportWaitResult{ID: id, Import: imports[id], Err: errs[id]})
+	}
+	return result, err
+}
@@ -0,0 +1,82 @@
+package sevenbridges
+
+import (
+	"context"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// Invoice holds information about costs charged to billing group in single
+// invoice period.
+type Invoice struct {
+	Href string `json:"href"`
+	ID   string `json:"invoice_id"`
+	// Pending is true for invoice of current period, which is not final yet.
+	Pending       bool           `json:"pending"`
+	ApprovalDate  time.Time      `json:"approval_date"`
+	Period        *InvoicePeriod `json:"invoice_period"`
+	AnalysisCosts *Money         `json:"analysis_costs"`
+	StorageCosts  *Money         `json:"storage_costs"`
+	Total         *Money         `json:"total"`
+}
+
+// InvoicePeriod is time range invoice covers.
+type InvoicePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// InvoiceListOptions specifies optional filters for listing invoices.
+type InvoiceListOptions struct {
+	ListOptions
+	// BillingGroup is ID of billing group whose invoices should be listed.
+	BillingGroup string `url:"billing_group,omitempty"`
+}
+
+// InvoiceService is interface that defines invoice related operations
+// available on SevenBridges platform.
+type InvoiceService interface {
+	// List returns invoices (single page) that match provided options.
+	List(ctx context.Context, opt *InvoiceListOptions) ([]*Invoice, *Response, error)
+	// ByID returns invoice with provided ID.
+	ByID(ctx context.Context, invoiceID string) (*Invoice, *Response, error)
+}
+
+type invoiceService struct {
+	*service
+}
+
+func newInvoiceService(client gwc.Doer) InvoiceService {
+	service := newService(client)
+	service.Use(url.AddPath("/billing/invoices"))
+	return &invoiceService{service}
+}
+
+// just make sure at compile time that invoiceService implements InvoiceService
+var _ InvoiceService = new(invoiceService)
+
+func (is *invoiceService) List(ctx context.Context, opt *InvoiceListOptions) ([]*Invoice, *Response, error) {
+	var i []*Invoice
+	resp, err := is.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&i),
+	)
+	return i, resp, err
+}
+
+func (is *invoiceService) ByID(ctx context.Context, invoiceID string) (*Invoice, *Response, error) {
+	i := new(Invoice)
+	resp, err := is.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+invoiceID),
+		responsebody.JSON(i),
+	)
+	return i, resp, err
+}
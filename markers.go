@@ -0,0 +1,155 @@
+package sevenbridges
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/query"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// ErrInvalidMarkerPosition is returned when marker position ends before it
+// starts.
+var ErrInvalidMarkerPosition = errors.New("sevenbridges: marker position end is before start")
+
+// Marker is annotation of region of a genome on a file (e.g. BAM), shown in
+// genome browser.
+type Marker struct {
+	Href       string          `json:"href"`
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	File       string          `json:"file"`
+	Chromosome string          `json:"chromosome"`
+	Position   *MarkerPosition `json:"position"`
+	Private    bool            `json:"private"`
+	CreatedBy  string          `json:"created_by"`
+	CreatedOn  time.Time       `json:"created_time"`
+}
+
+// MarkerPosition is range of positions on chromosome marker annotates. Both
+// start and end are inclusive.
+type MarkerPosition struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// valid returns ErrInvalidMarkerPosition if position is not valid range.
+func (p *MarkerPosition) valid() error {
+	if p != nil && p.End < p.Start {
+		return ErrInvalidMarkerPosition
+	}
+	return nil
+}
+
+// MarkerCreate is structure that defines body required for creating new
+// marker.
+type MarkerCreate struct {
+	Name string `json:"name"`
+	// File is ID of annotated file.
+	File       string          `json:"file"`
+	Chromosome string          `json:"chromosome,omitempty"`
+	Position   *MarkerPosition `json:"position"`
+	// Private is flag marking that marker is visible only to its creator.
+	Private bool `json:"private,omitempty"`
+}
+
+// MarkerModify is structure that defines body for modifying marker. Only
+// provided fields are modified.
+type MarkerModify struct {
+	Name       string          `json:"name,omitempty"`
+	Chromosome string          `json:"chromosome,omitempty"`
+	Position   *MarkerPosition `json:"position,omitempty"`
+}
+
+// MarkerService is interface that defines marker related operations available
+// on SevenBridges platform.
+type MarkerService interface {
+	// List returns markers (single page) on file with provided ID.
+	List(ctx context.Context, fileID string, opt *ListOptions) ([]*Marker, *Response, error)
+	// ByID returns marker with provided ID.
+	ByID(ctx context.Context, markerID string) (*Marker, *Response, error)
+	// Create creates new marker.
+	Create(ctx context.Context, mc MarkerCreate) (*Marker, *Response, error)
+	// Modify edits marker with provided ID.
+	Modify(ctx context.Context, markerID string, mm MarkerModify) (*Marker, *Response, error)
+	// Delete removes marker with provided ID.
+	Delete(ctx context.Context, markerID string) (*Response, error)
+}
+
+type markerService struct {
+	*service
+}
+
+func newMarkerService(client gwc.Doer) MarkerService {
+	service := newService(client)
+	service.Use(url.AddPath("/genomics/markers"))
+	return &markerService{service}
+}
+
+// just make sure at compile time that markerService implements MarkerService
+var _ MarkerService = new(markerService)
+
+func (ms *markerService) List(ctx context.Context, fileID string, opt *ListOptions) ([]*Marker, *Response, error) {
+	var m []*Marker
+	resp, err := ms.Do(
+		ctx,
+		headers.Method("GET"),
+		query.Add("file", fileID),
+		listOptions(opt),
+		pageResponse(&m),
+	)
+	return m, resp, err
+}
+
+func (ms *markerService) ByID(ctx context.Context, markerID string) (*Marker, *Response, error) {
+	m := new(Marker)
+	resp, err := ms.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+markerID),
+		responsebody.JSON(m),
+	)
+	return m, resp, err
+}
+
+func (ms *markerService) Create(ctx context.Context, mc MarkerCreate) (*Marker, *Response, error) {
+	if err := mc.Position.valid(); err != nil {
+		return nil, nil, err
+	}
+	m := new(Marker)
+	resp, err := ms.Do(
+		ctx,
+		headers.Method("POST"),
+		body.JSON(mc),
+		responsebody.JSON(m),
+	)
+	return m, resp, err
+}
+
+func (ms *markerService) Modify(ctx context.Context, markerID string, mm MarkerModify) (*Marker, *Response, error) {
+	if err := mm.Position.valid(); err != nil {
+		return nil, nil, err
+	}
+	m := new(Marker)
+	resp, err := ms.Do(
+		ctx,
+		headers.Method("PATCH"),
+		url.AddPath("/"+markerID),
+		body.JSON(mm),
+		responsebody.JSON(m),
+	)
+	return m, resp, err
+}
+
+func (ms *markerService) Delete(ctx context.Context, markerID string) (*Response, error) {
+	return ms.Do(
+		ctx,
+		headers.Method("DELETE"),
+		url.AddPath("/"+markerID),
+	)
+}
@@ -15,16 +15,21 @@ import (
 
 // listOptions adds provided list options to request (in form of query parameters)
 func listOptions(listOptions *ListOptions) c.Middleware {
+	return queryOptions(listOptions)
+}
+
+// queryOptions adds fields of provided struct to request as query parameters,
+// as defined by their "url" tags. Existing parameters with the same name are
+// replaced.
+func queryOptions(opt interface{}) c.Middleware {
 	return c.RequestProcessor(func(req *http.Request) error {
 		q := req.URL.Query()
-		newValues, err := query.Values(listOptions)
+		newValues, err := query.Values(opt)
 		if err != nil {
 			return err
 		}
 		for k, v := range newValues {
-			for _, vv := range v {
-				q.Set(k, vv)
-			}
+			q[k] = v
 		}
 		req.URL.RawQuery = q.Encode()
 		return nil
@@ -70,6 +75,11 @@ func pageResponse(data interface{}) c.Middleware {
 type HTTPError struct {
 	OriginalError *errors.HTTPError
 	Info          *ErrorInfo
+	// StatusCode is HTTP status code of response, 0 if it is not known.
+	StatusCode int
+	// Rate holds rate limit information returned with error response, nil if
+	// it is not known.
+	Rate *Rate
 }
 
 // ErrorInfo holds information about error that occurred on SevenBridges server.
@@ -80,6 +90,11 @@ type ErrorInfo struct {
 	MoreInfo string `json:"more_info"`
 }
 
+// Implementation of error interface
+func (ei *ErrorInfo) Error() string {
+	return fmt.Sprintf("[Status: %d, Code: %d, Message: %s, More info: %s]", ei.Status, ei.Code, ei.Message, ei.MoreInfo)
+}
+
 // Implementation of error interface
 func (he *HTTPError) Error() string {
 	if he.Info.Message != "" || he.Info.MoreInfo != "" || he.Info.Code != 0 {
@@ -103,9 +118,17 @@ func errorHandler() c.Middleware {
 			if httpError, ok := err.(*errors.HTTPError); ok {
 				info := new(ErrorInfo)
 				json.Unmarshal(httpError.Body, info)
+				statusCode := info.Status
+				var rate *Rate
+				if resp != nil {
+					statusCode = resp.StatusCode
+					rate, _ = getRateLimit(resp)
+				}
 				return &HTTPError{
 					OriginalError: httpError,
 					Info:          info,
+					StatusCode:    statusCode,
+					Rate:          rate,
 				}
 			}
 			return err
@@ -0,0 +1,178 @@
+package sevenbridges
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// amountScale is number of units of Amount in one unit of currency.
+const amountScale = 1000000
+
+// ErrCurrencyMismatch is returned when arithmetic is attempted on amounts of
+// money in different currencies.
+var ErrCurrencyMismatch = errors.New("sevenbridges: currencies do not match")
+
+// ErrAmountOverflow is returned when result of arithmetic on amounts of money
+// does not fit in Amount.
+var ErrAmountOverflow = errors.New("sevenbridges: amount out of range")
+
+// Amount is decimal number stored as fixed point value with precision of one
+// millionth. It is used instead of float64 for monetary values so that
+// summing costs does not accumulate rounding errors. Amount can be
+// deserialized from both JSON number and string.
+type Amount int64
+
+// ParseAmount parses decimal number in form of "-12.345" or "1.5e-05".
+// Digits after sixth decimal place are rounded. Error is returned if number
+// does not fit in Amount.
+func ParseAmount(s string) (Amount, error) {
+	invalid := fmt.Errorf("sevenbridges: invalid amount %q", s)
+	str := strings.TrimSpace(s)
+	negative := strings.HasPrefix(str, "-")
+	if negative || strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+	exponent := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(str[i+1:])
+		if err != nil && !isRangeError(err) {
+			return 0, invalid
+		}
+		// larger exponents either round amount to zero or overflow it, so
+		// there is no need to pad digits any further
+		switch {
+		case exp > maxAmountExponent:
+			exp = maxAmountExponent
+		case exp < -maxAmountExponent:
+			exp = -maxAmountExponent
+		}
+		exponent, str = exp, str[:i]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if (intPart == "" && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, invalid
+	}
+
+	// digits of amount in millionths are all digits of number up to point
+	// moved by exponent and six decimal places
+	digits := intPart + fracPart
+	point := len(intPart) + exponent + 6
+	round := false
+	switch {
+	case point <= 0:
+		round = point == 0 && digits[0] >= '5'
+		digits = "0"
+	case point >= len(digits):
+		digits += strings.Repeat("0", point-len(digits))
+	default:
+		round = digits[point] >= '5'
+		digits = digits[:point]
+	}
+	a, err := strconv.ParseInt("0"+strings.TrimLeft(digits, "0"), 10, 64)
+	if err != nil || (round && a == math.MaxInt64) {
+		return 0, fmt.Errorf("sevenbridges: amount %q out of range", s)
+	}
+	if round {
+		a++
+	}
+	if negative {
+		a = -a
+	}
+	return Amount(a), nil
+}
+
+// maxAmountExponent is largest absolute value of exponent that can affect
+// parsed amount.
+const maxAmountExponent = 100
+
+// isRangeError returns true if provided error is returned by strconv for
+// number out of range.
+func isRangeError(err error) bool {
+	numErr, ok := err.(*strconv.NumError)
+	return ok && numErr.Err == strconv.ErrRange
+}
+
+// isDigits returns true if provided string consists only of decimal digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns decimal representation of amount, without trailing zeros.
+func (a Amount) String() string {
+	sign := ""
+	// absolute value of smallest Amount does not fit in int64
+	v := uint64(a)
+	if a < 0 {
+		sign, v = "-", -v
+	}
+	s := sign + strconv.FormatUint(v/amountScale, 10)
+	if frac := v % amountScale; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+	}
+	return s
+}
+
+// Float64 returns amount as floating point number. It should be used only for
+// displaying or approximate calculations.
+func (a Amount) Float64() float64 {
+	return float64(a) / amountScale
+}
+
+// MarshalJSON serializes amount as JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON deserializes amount from JSON number or string.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	parsed, err := ParseAmount(string(bytes.Trim(b, `"`)))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Money is amount of money in specific currency.
+type Money struct {
+	Currency string `json:"currency"`
+	Amount   Amount `json:"amount"`
+}
+
+// String returns amount followed by currency, e.g. "12.5 USD".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// Add returns sum of m and other. ErrCurrencyMismatch is returned if
+// currencies differ and ErrAmountOverflow if sum does not fit in Amount.
+// Zero value of Money can be added to any amount.
+func (m Money) Add(other Money) (Money, error) {
+	switch {
+	case m.Currency == "":
+		m.Currency = other.Currency
+	case other.Currency != "" && other.Currency != m.Currency:
+		return m, ErrCurrencyMismatch
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return m, ErrAmountOverflow
+	}
+	m.Amount = sum
+	return m, nil
+}
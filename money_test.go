@@ -0,0 +1,81 @@
+package sevenbridges_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+func TestMoneyJSON(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{`{"currency": "USD", "amount": 12.34}`, "12.34 USD"},
+		{`{"currency": "USD", "amount": "0.1"}`, "0.1 USD"},
+		{`{"currency": "EUR", "amount": -3}`, "-3 EUR"},
+		{`{"currency": "USD", "amount": 0.00000049}`, "0 USD"},
+		{`{"currency": "USD", "amount": 1.0000005}`, "1.000001 USD"},
+		{`{"currency": "USD", "amount": 1e-05}`, "0.00001 USD"},
+		{`{"currency": "USD", "amount": 1.5E2}`, "150 USD"},
+		{`{"currency": "USD", "amount": "5e-7"}`, "0.000001 USD"},
+		{`{"currency": "USD", "amount": 1e-400}`, "0 USD"},
+		{`{"currency": "USD", "amount": 9223372036854.775807}`, "9223372036854.775807 USD"},
+		{`{"currency": "USD", "amount": -9223372036854.775807}`, "-9223372036854.775807 USD"},
+	} {
+		var m sevenbridges.Money
+		if err := json.Unmarshal([]byte(tc.in), &m); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.in, err)
+			continue
+		}
+		if m.String() != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.in, m.String(), tc.want)
+		}
+	}
+
+	for _, in := range []string{
+		`{"currency": "USD", "amount": "1.2.3"}`,
+		`{"currency": "USD", "amount": "1e"}`,
+		`{"currency": "USD", "amount": "1e+-5"}`,
+		`{"currency": "USD", "amount": 1e20}`,
+		`{"currency": "USD", "amount": 1e400}`,
+		`{"currency": "USD", "amount": 9223372036855}`,
+		`{"currency": "USD", "amount": 9223372036854.7758075}`,
+	} {
+		var m sevenbridges.Money
+		if err := json.Unmarshal([]byte(in), &m); err == nil {
+			t.Errorf("%s: expected error, got %s", in, m)
+		}
+	}
+}
+
+func TestMoneyAdd(t *testing.T) {
+	var total sevenbridges.Money
+	for i := 0; i < 10; i++ {
+		var err error
+		total, err = total.Add(sevenbridges.Money{Currency: "USD", Amount: 100000})
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+	}
+	if total.String() != "1 USD" {
+		t.Errorf("got %q, want \"1 USD\"", total.String())
+	}
+	if _, err := total.Add(sevenbridges.Money{Currency: "EUR"}); err != sevenbridges.ErrCurrencyMismatch {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	max := sevenbridges.Money{Currency: "USD", Amount: math.MaxInt64}
+	if _, err := max.Add(sevenbridges.Money{Currency: "USD", Amount: 1}); err != sevenbridges.ErrAmountOverflow {
+		t.Errorf("expected ErrAmountOverflow, got %v", err)
+	}
+	min := sevenbridges.Money{Currency: "USD", Amount: -math.MaxInt64}
+	if _, err := min.Add(sevenbridges.Money{Currency: "USD", Amount: -2}); err != sevenbridges.ErrAmountOverflow {
+		t.Errorf("expected ErrAmountOverflow, got %v", err)
+	}
+	if sum, err := min.Add(sevenbridges.Money{Currency: "USD", Amount: -1}); err != nil || sum.String() != "-9223372036854.775808 USD" {
+		t.Errorf("got %q, %v, want \"-9223372036854.775808 USD\"", sum, err)
+	}
+}
@@ -0,0 +1,92 @@
+package sevenbridges
+
+import (
+	"context"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+const (
+	// FeedbackIdea is type of feedback suggesting new feature.
+	FeedbackIdea = "IDEA"
+	// FeedbackProblem is type of feedback reporting a problem.
+	FeedbackProblem = "PROBLEM"
+	// FeedbackThought is type of general feedback.
+	FeedbackThought = "THOUGHT"
+)
+
+// Notification holds information about notification platform sent to
+// current user.
+type Notification struct {
+	Href      string    `json:"href"`
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	Read      bool      `json:"read"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// NotificationListOptions specifies optional filters for listing
+// notifications.
+type NotificationListOptions struct {
+	ListOptions
+	// Unread limits result to notifications that have not been read.
+	Unread bool `url:"unread,omitempty"`
+}
+
+// Feedback is structure that defines body required for sending feedback to
+// SevenBridges.
+type Feedback struct {
+	// Type is one of FeedbackIdea, FeedbackProblem and FeedbackThought.
+	Type string `json:"type"`
+	Text string `json:"text"`
+	// Referrer is optional identifier of place feedback is sent from.
+	Referrer string `json:"referrer,omitempty"`
+}
+
+// NotificationService is interface that defines notification related
+// operations and actions available on SevenBridges platform.
+type NotificationService interface {
+	// List returns notifications (single page) of current user that match
+	// provided options.
+	List(ctx context.Context, opt *NotificationListOptions) ([]*Notification, *Response, error)
+	// SendFeedback sends feedback to SevenBridges.
+	SendFeedback(ctx context.Context, feedback Feedback) (*Response, error)
+}
+
+type notificationService struct {
+	*service
+}
+
+func newNotificationService(client gwc.Doer) NotificationService {
+	return &notificationService{newService(client)}
+}
+
+// just make sure at compile time that notificationService implements NotificationService
+var _ NotificationService = new(notificationService)
+
+func (ns *notificationService) List(ctx context.Context, opt *NotificationListOptions) ([]*Notification, *Response, error) {
+	var n []*Notification
+	resp, err := ns.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/notifications"),
+		queryOptions(opt),
+		pageResponse(&n),
+	)
+	return n, resp, err
+}
+
+func (ns *notificationService) SendFeedback(ctx context.Context, feedback Feedback) (*Response, error) {
+	return ns.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/action/notifications/feedback"),
+		body.JSON(feedback),
+	)
+}
@@ -0,0 +1,236 @@
+package sevenbridges
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// newPermissions returns permissions with provided values.
+func newPermissions(read, write, copy, execute, admin bool) Permissions {
+	return Permissions{
+		Read:    &read,
+		Write:   &write,
+		Copy:    &copy,
+		Execute: &execute,
+		Admin:   &admin,
+	}
+}
+
+// PermissionsReadOnly returns permissions that allow only viewing project
+// content.
+func PermissionsReadOnly() Permissions {
+	return newPermissions(true, false, false, false, false)
+}
+
+// PermissionsContributor returns permissions that allow viewing, adding and
+// copying project content.
+func PermissionsContributor() Permissions {
+	return newPermissions(true, true, true, false, false)
+}
+
+// PermissionsExecutor returns permissions of contributor that can also run
+// tasks.
+func PermissionsExecutor() Permissions {
+	return newPermissions(true, true, true, true, false)
+}
+
+// PermissionsAdmin returns all permissions, including managing project
+// members.
+func PermissionsAdmin() Permissions {
+	return newPermissions(true, true, true, true, true)
+}
+
+// Equal returns true if p and other grant the same permissions. Missing
+// values are treated as false.
+func (p Permissions) Equal(other Permissions) bool {
+	return boolValue(p.Read) == boolValue(other.Read) &&
+		boolValue(p.Write) == boolValue(other.Write) &&
+		boolValue(p.Copy) == boolValue(other.Copy) &&
+		boolValue(p.Execute) == boolValue(other.Execute) &&
+		boolValue(p.Admin) == boolValue(other.Admin)
+}
+
+// String returns comma separated list of granted permissions.
+func (p Permissions) String() string {
+	var granted []string
+	for _, perm := range []struct {
+		name  string
+		value *bool
+	}{
+		{"read", p.Read},
+		{"write", p.Write},
+		{"copy", p.Copy},
+		{"execute", p.Execute},
+		{"admin", p.Admin},
+	} {
+		if boolValue(perm.value) {
+			granted = append(granted, perm.name)
+		}
+	}
+	if len(granted) == 0 {
+		return "none"
+	}
+	return strings.Join(granted, ",")
+}
+
+// boolValue returns value of provided pointer or false if it is nil.
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// MemberAction is change to single project member.
+type MemberAction string
+
+const (
+	// MemberAdd is action of adding new project member.
+	MemberAdd MemberAction = "add"
+	// MemberUpdate is action of changing permissions of project member.
+	MemberUpdate MemberAction = "update"
+	// MemberRemove is action of removing project member.
+	MemberRemove MemberAction = "remove"
+)
+
+// MemberChange is single change needed to bring project members to desired
+// state.
+type MemberChange struct {
+	Action   MemberAction
+	Username string
+	// Permissions are new permissions of member, nil for MemberRemove.
+	Permissions *Permissions
+	// Current are permissions member has now, nil for MemberAdd.
+	Current *Permissions
+}
+
+// String returns human readable description of change.
+func (mc *MemberChange) String() string {
+	switch mc.Action {
+	case MemberAdd:
+		return fmt.Sprintf("+ %s (%s)", mc.Username, mc.Permissions)
+	case MemberUpdate:
+		return fmt.Sprintf("~ %s (%s -> %s)", mc.Username, mc.Current, mc.Permissions)
+	default:
+		return fmt.Sprintf("- %s (%s)", mc.Username, mc.Current)
+	}
+}
+
+// MemberSyncPlan is list of changes needed to bring members of a project to
+// desired state.
+type MemberSyncPlan struct {
+	ProjectID string
+	Changes   []*MemberChange
+}
+
+// String returns human readable description of all changes, one per line.
+func (p *MemberSyncPlan) String() string {
+	if len(p.Changes) == 0 {
+		return p.ProjectID + ": no changes"
+	}
+	lines := make([]string, 0, len(p.Changes)+1)
+	lines = append(lines, p.ProjectID+":")
+	for _, c := range p.Changes {
+		lines = append(lines, "  "+c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (ps *projectService) PlanMemberSync(ctx context.Context, projectID string, desired map[string]Permissions) (*MemberSyncPlan, error) {
+	current := map[string]Permissions{}
+	opt := new(ListOptions)
+	for {
+		members, resp, err := ps.Members(ctx, projectID, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			// teams are not managed by sync
+			if m.Username == nil || (m.Type != nil && *m.Type == MemberTypeTeam) {
+				continue
+			}
+			var p Permissions
+			if m.Permissions != nil {
+				p = *m.Permissions
+			}
+			current[*m.Username] = p
+		}
+		if !resp.HasNextPage() {
+			break
+		}
+		opt = resp.NextPage()
+	}
+
+	// current user and project owner are never removed, since that would
+	// lock them out of the project
+	me, _, err := ps.users.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := ps.ByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	protected := map[string]bool{me.Username: true}
+	if project.CreatedBy != nil {
+		protected[*project.CreatedBy] = true
+	}
+
+	plan := &MemberSyncPlan{ProjectID: projectID}
+	for username, want := range desired {
+		want := want
+		have, ok := current[username]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, &MemberChange{Action: MemberAdd, Username: username, Permissions: &want})
+		case !have.Equal(want):
+			have := have
+			plan.Changes = append(plan.Changes, &MemberChange{Action: MemberUpdate, Username: username, Permissions: &want, Current: &have})
+		}
+	}
+	for username, have := range current {
+		if _, ok := desired[username]; !ok && !protected[username] {
+			have := have
+			plan.Changes = append(plan.Changes, &MemberChange{Action: MemberRemove, Username: username, Current: &have})
+		}
+	}
+	// removals go last, so that project is never left without admins because
+	// of partially applied plan, and change of current user goes after them,
+	// since it might take away permissions needed for other changes
+	order := map[MemberAction]int{MemberAdd: 0, MemberUpdate: 1, MemberRemove: 2}
+	rank := func(c *MemberChange) int {
+		if c.Username == me.Username {
+			return len(order)
+		}
+		return order[c.Action]
+	}
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		ci, cj := plan.Changes[i], plan.Changes[j]
+		if ri, rj := rank(ci), rank(cj); ri != rj {
+			return ri < rj
+		}
+		return ci.Username < cj.Username
+	})
+	return plan, nil
+}
+
+func (ps *projectService) SyncMembers(ctx context.Context, projectID string, desired map[string]Permissions) (*MemberSyncPlan, error) {
+	plan, err := ps.PlanMemberSync(ctx, projectID, desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case MemberAdd:
+			username := c.Username
+			_, _, err = ps.AddMember(ctx, projectID, &Member{Username: &username, Permissions: c.Permissions})
+		case MemberUpdate:
+			_, _, err = ps.ChangePermissions(ctx, projectID, c.Username, *c.Permissions)
+		case MemberRemove:
+			_, err = ps.RemoveMember(ctx, projectID, c.Username)
+		}
+		if err != nil {
+			return plan, fmt.Errorf("sevenbridges: %s member %s: %v", c.Action, c.Username, err)
+		}
+	}
+	return plan, nil
+}
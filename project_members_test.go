@@ -0,0 +1,92 @@
+package sevenbridges_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+func TestSyncMembers(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/user" {
+			w.Write([]byte(`{"username": "erin"}`))
+			return
+		}
+		if r.Method == "GET" && r.URL.Path == "/projects/division/project" {
+			// owner of division project is not in its ID
+			w.Write([]byte(`{"id": "division/project", "created_by": "frank"}`))
+			return
+		}
+		if r.Method == "GET" && r.URL.Path == "/projects/division/project/members" {
+			w.Write([]byte(`{"items": [
+				{"username": "alice", "type": "USER", "permissions": {"read": true, "write": true, "copy": true, "execute": true, "admin": true}},
+				{"username": "bob", "type": "USER", "permissions": {"read": true}},
+				{"username": "carol", "type": "USER", "permissions": {"read": true}},
+				{"username": "frank", "type": "USER", "permissions": {"read": true, "admin": true}},
+				{"username": "erin", "type": "USER", "permissions": {"read": true, "admin": true}},
+				{"id": "division/team", "type": "TEAM", "permissions": {"read": true}}
+			]}`))
+			return
+		}
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	desired := map[string]sevenbridges.Permissions{
+		"alice": sevenbridges.PermissionsAdmin(),
+		"bob":   sevenbridges.PermissionsExecutor(),
+		"dave":  sevenbridges.PermissionsReadOnly(),
+		"erin":  sevenbridges.PermissionsReadOnly(),
+	}
+	plan, err := client.Project.PlanMemberSync(context.Background(), "division/project", desired)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	mu.Lock()
+	if len(calls) != 0 {
+		t.Errorf("Plan should not modify members, got calls: %v", calls)
+	}
+	mu.Unlock()
+	// current user is demoted only after all other changes
+	expected := "division/project:\n" +
+		"  + dave (read)\n" +
+		"  ~ bob (read -> read,write,copy,execute)\n" +
+		"  - carol (read)\n" +
+		"  ~ erin (read,admin -> read)"
+	if plan.String() != expected {
+		t.Errorf("Unexpected plan:\n%s", plan)
+	}
+
+	if _, err := client.Project.SyncMembers(context.Background(), "division/project", desired); err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	expectedCalls := []string{
+		"POST /projects/division/project/members",
+		"PUT /projects/division/project/members/bob",
+		"DELETE /projects/division/project/members/carol",
+		"PUT /projects/division/project/members/erin",
+	}
+	if len(calls) != len(expectedCalls) {
+		t.Fatalf("Expected calls %v, got %v", expectedCalls, calls)
+	}
+	for i := range calls {
+		if calls[i] != expectedCalls[i] {
+			t.Errorf("Expected calls %v, got %v", expectedCalls, calls)
+			break
+		}
+	}
+}
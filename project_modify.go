@@ -0,0 +1,121 @@
+package sevenbridges
+
+import "encoding/json"
+
+// ProjectModify describes changes to a project. Only fields that are
+// explicitly set or unset are sent to server, so unlike pointer fields there
+// is no ambiguity between "leave unchanged" and "clear". Zero value is ready
+// to use and methods can be chained:
+//
+//	pm := new(ProjectModify).SetDescription("RNA-seq").UnsetCategory().SetLocked(true)
+type ProjectModify struct {
+	fields   map[string]interface{}
+	settings map[string]interface{}
+}
+
+// set records new value of provided field. nil value unsets the field.
+func (pm *ProjectModify) set(field string, value interface{}) *ProjectModify {
+	if pm.fields == nil {
+		pm.fields = make(map[string]interface{})
+	}
+	pm.fields[field] = value
+	return pm
+}
+
+// setting records new value of provided setting. nil value resets setting to
+// platform default.
+func (pm *ProjectModify) setting(name string, value interface{}) *ProjectModify {
+	if pm.settings == nil {
+		pm.settings = make(map[string]interface{})
+	}
+	pm.settings[name] = value
+	return pm
+}
+
+// SetName changes project name.
+func (pm *ProjectModify) SetName(name string) *ProjectModify {
+	return pm.set("name", name)
+}
+
+// SetDescription changes project description.
+func (pm *ProjectModify) SetDescription(description string) *ProjectModify {
+	return pm.set("description", description)
+}
+
+// UnsetDescription removes project description.
+func (pm *ProjectModify) UnsetDescription() *ProjectModify {
+	return pm.set("description", nil)
+}
+
+// SetBillingGroup changes billing group project is charged to.
+func (pm *ProjectModify) SetBillingGroup(billingGroup string) *ProjectModify {
+	return pm.set("billing_group", billingGroup)
+}
+
+// SetTags replaces all project tags with provided ones.
+func (pm *ProjectModify) SetTags(tags ...string) *ProjectModify {
+	if tags == nil {
+		tags = []string{}
+	}
+	return pm.set("tags", tags)
+}
+
+// UnsetTags removes all project tags.
+func (pm *ProjectModify) UnsetTags() *ProjectModify {
+	return pm.set("tags", []string{})
+}
+
+// SetCategory changes project category.
+func (pm *ProjectModify) SetCategory(category string) *ProjectModify {
+	return pm.set("category", category)
+}
+
+// UnsetCategory resets project category to platform default.
+func (pm *ProjectModify) UnsetCategory() *ProjectModify {
+	return pm.set("category", nil)
+}
+
+// SetLocked locks or unlocks project content.
+func (pm *ProjectModify) SetLocked(locked bool) *ProjectModify {
+	return pm.setting(SettingLocked, locked)
+}
+
+// SetUseInterruptibleInstances changes whether tasks in project run on
+// interruptible (spot) instances.
+func (pm *ProjectModify) SetUseInterruptibleInstances(use bool) *ProjectModify {
+	return pm.setting(SettingUseInterruptibleInstances, use)
+}
+
+// SetUseMemoization changes whether results of previously executed jobs are
+// reused.
+func (pm *ProjectModify) SetUseMemoization(use bool) *ProjectModify {
+	return pm.setting(SettingUseMemoization, use)
+}
+
+// SetAllowNetworkAccess changes whether tasks in project can access network.
+func (pm *ProjectModify) SetAllowNetworkAccess(allow bool) *ProjectModify {
+	return pm.setting(SettingAllowNetworkAccess, allow)
+}
+
+// SetIntermediateFiles changes retention of intermediate files.
+func (pm *ProjectModify) SetIntermediateFiles(files IntermediateFiles) *ProjectModify {
+	return pm.setting(SettingIntermediateFiles, files)
+}
+
+// ResetSetting resets setting with provided name (one of Setting* constants)
+// to platform default.
+func (pm *ProjectModify) ResetSetting(name string) *ProjectModify {
+	return pm.setting(name, nil)
+}
+
+// MarshalJSON serializes only fields and settings that were set or unset.
+func (pm *ProjectModify) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{}, len(pm.fields)+1)
+	for k, v := range pm.fields {
+		data[k] = v
+	}
+	if len(pm.settings) > 0 {
+		data["settings"] = pm.settings
+	}
+	return json.Marshal(data)
+}
@@ -2,6 +2,8 @@ package sevenbridges
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/delicb/cliware-middlewares/body"
 	"github.com/delicb/cliware-middlewares/headers"
@@ -12,19 +14,106 @@ import (
 
 // Project holds information about project on Seven Bridges platform.
 type Project struct {
-	Href         *string `json:"href"`
-	ID           *string `json:"id"`
-	Name         *string `json:"name"`
-	Type         *string `json:"type"`
-	BillingGroup *string `json:"billing_group"`
+	Href         *string          `json:"href"`
+	ID           *string          `json:"id"`
+	Name         *string          `json:"name"`
+	Type         *string          `json:"type"`
+	BillingGroup *string          `json:"billing_group"`
+	Description  *string          `json:"description"`
+	Tags         []string         `json:"tags"`
+	Settings     *ProjectSettings `json:"settings"`
+	Category     *string          `json:"category"`
+	CreatedBy    *string          `json:"created_by"`
+	CreatedOn    *time.Time       `json:"created_on"`
+	ModifiedOn   *time.Time       `json:"modified_on"`
 }
 
+// ProjectSettings holds settings of a project that affect task execution.
+type ProjectSettings struct {
+	// Locked is flag marking that project content can not be modified.
+	Locked                    *bool              `json:"locked,omitempty"`
+	UseInterruptibleInstances *bool              `json:"use_interruptible_instances,omitempty"`
+	UseMemoization            *bool              `json:"use_memoization,omitempty"`
+	AllowNetworkAccess        *bool              `json:"allow_network_access,omitempty"`
+	IntermediateFiles         *IntermediateFiles `json:"intermediate_files,omitempty"`
+}
+
+// IntermediateFiles defines how long intermediate files produced by tasks
+// are kept.
+type IntermediateFiles struct {
+	// Retention is either RetentionLifetime or RetentionLimited.
+	Retention string `json:"retention"`
+	// Duration is number of hours files are kept for RetentionLimited.
+	Duration *int `json:"duration,omitempty"`
+}
+
+const (
+	// ProjectCategoryPrivate is category of regular projects.
+	ProjectCategoryPrivate = "PRIVATE"
+	// ProjectCategoryControlled is category of projects with controlled data.
+	ProjectCategoryControlled = "CONTROLLED"
+
+	// RetentionLifetime keeps intermediate files for lifetime of project.
+	RetentionLifetime = "LIFETIME"
+	// RetentionLimited keeps intermediate files for limited number of hours.
+	RetentionLimited = "LIMITED"
+
+	// Names of project settings, for use with ProjectModify.ResetSetting.
+	SettingLocked                    = "locked"
+	SettingUseInterruptibleInstances = "use_interruptible_instances"
+	SettingUseMemoization            = "use_memoization"
+	SettingAllowNetworkAccess        = "allow_network_access"
+	SettingIntermediateFiles         = "intermediate_files"
+
+	// MemberTypeUser is type of project member that is single user.
+	MemberTypeUser = "USER"
+	// MemberTypeTeam is type of project member that is team. All team members
+	// get permissions of the team.
+	MemberTypeTeam = "TEAM"
+)
+
 // Member contains information about project member with user info and project
-// permission that member has.
+// permission that member has. Member can be either single user, identified
+// by Username, or team, identified by ID with Type set to MemberTypeTeam.
 type Member struct {
+	Href        *string      `json:"href,omitempty"`
+	ID          *string      `json:"id,omitempty"`
+	Username    *string      `json:"username,omitempty"`
+	Email       *string      `json:"email,omitempty"`
+	Type        *string      `json:"type,omitempty"`
+	Permissions *Permissions `json:"permissions"`
+}
+
+// Invitation holds information about pending invitation to a project, sent
+// to user that has not accepted it yet.
+type Invitation struct {
 	Href        *string      `json:"href"`
+	ID          *string      `json:"id"`
+	Email       *string      `json:"email"`
 	Username    *string      `json:"username"`
 	Permissions *Permissions `json:"permissions"`
+	CreatedOn   *time.Time   `json:"created_on"`
+}
+
+// NewEmailMember returns project member for collaborator with provided email
+// and permissions, that can be used with AddMember. Collaborator without
+// account on platform gets an invitation.
+func NewEmailMember(email string, permissions Permissions) *Member {
+	return &Member{
+		Email:       &email,
+		Permissions: &permissions,
+	}
+}
+
+// NewTeamMember returns project member for team with provided ID and
+// permissions, that can be used with AddMember.
+func NewTeamMember(teamID string, permissions Permissions) *Member {
+	memberType := MemberTypeTeam
+	return &Member{
+		ID:          &teamID,
+		Type:        &memberType,
+		Permissions: &permissions,
+	}
 }
 
 // Permissions holds set of permissions of a single user on particular project.
@@ -39,9 +128,12 @@ type Permissions struct {
 // ProjectCreate is structure that defines body that is required for creating
 // new project on SevenBridges platform.
 type ProjectCreate struct {
-	Name         *string `json:"name,omitempty"`
-	Description  *string `json:"description,omitempty"`
-	BillingGroup *string `json:"billing_group,omitempty"`
+	Name         *string          `json:"name,omitempty"`
+	Description  *string          `json:"description,omitempty"`
+	BillingGroup *string          `json:"billing_group,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	Settings     *ProjectSettings `json:"settings,omitempty"`
+	Category     *string          `json:"category,omitempty"`
 }
 
 // ProjectService is interface that defines project related operations available
@@ -58,33 +150,54 @@ type ProjectService interface {
 	// Delete removes project from SBG platform, including all stuff stored in
 	// project (files, apps, workflows, tasks...).
 	Delete(ctx context.Context, projectID string) (*Response, error)
-	// Modify edits project with provided ID with provided data. Not all fields
-	// for project modification needs to be supplied, only fields that needs to
-	// be modified.
-	Modify(ctx context.Context, projectID string, pc ProjectCreate) (*Project, *Response, error)
+	// Modify edits project with provided ID. Only fields explicitly set or
+	// unset on provided ProjectModify are changed.
+	Modify(ctx context.Context, projectID string, pm *ProjectModify) (*Project, *Response, error)
 	// Members returns members of provided project
 	Members(ctx context.Context, projectID string, opt *ListOptions) ([]*Member, *Response, error)
 	// AddMember adds new member to project with provided ID and member
-	// information (including permissions).
+	// information (including permissions). Member can be a user or a team
+	// (see NewTeamMember), or a collaborator identified by email (see
+	// NewEmailMember).
 	AddMember(ctx context.Context, projectID string, member *Member) (*Member, *Response, error)
+	// AddCollaborator adds user identified by username or email to project
+	// with provided ID. Username is first resolved to make sure user exists,
+	// while email is sent as is, so collaborators without account get an
+	// invitation.
+	AddCollaborator(ctx context.Context, projectID, usernameOrEmail string, permissions Permissions) (*Member, *Response, error)
+	// Invitations returns pending invitations (single page) to project with
+	// provided ID.
+	Invitations(ctx context.Context, projectID string, opt *ListOptions) ([]*Invitation, *Response, error)
 	// RemoveMember removes user from project membership.
 	RemoveMember(ctx context.Context, projectID, username string) (*Response, error)
 	// GetMember returns member with provided username from project with provided ID.
 	GetMember(ctx context.Context, projectID, username string) (*Member, *Response, error)
 	// ChangePermissions updates permissions of a project member in specified
 	// project. All fields in provided permissions struct has to be filled,
-	// because any unfilled field will be defaulted to false.
+	// because any unfilled field will be defaulted to false. Presets, like
+	// PermissionsContributor, can be used to get filled permissions.
 	ChangePermissions(ctx context.Context, projectID, username string, permissions Permissions) (*Permissions, *Response, error)
+	// PlanMemberSync compares members of project with provided ID with
+	// desired members (username to permissions) and returns changes needed
+	// to make them equal, without applying them. Team members are ignored.
+	// Current user and project owner (its creator) are never removed, even
+	// if they are not in desired members. Change of current user is applied
+	// last, since it might take away permissions needed for other changes.
+	PlanMemberSync(ctx context.Context, projectID string, desired map[string]Permissions) (*MemberSyncPlan, error)
+	// SyncMembers applies changes returned by PlanMemberSync and returns
+	// them. If some change fails, applying stops and error is returned.
+	SyncMembers(ctx context.Context, projectID string, desired map[string]Permissions) (*MemberSyncPlan, error)
 }
 
 type projectService struct {
 	*service
+	users UserService
 }
 
 func newProjectService(client gwc.Doer) ProjectService {
 	service := newService(client)
 	service.Use(url.AddPath("/projects"))
-	return &projectService{service}
+	return &projectService{service, newUserService(client)}
 }
 
 // just make sure at compile time that projectService implements ProjectService
@@ -143,13 +256,13 @@ func (ps *projectService) Delete(ctx context.Context, projectID string) (*Respon
 	)
 }
 
-func (ps *projectService) Modify(ctx context.Context, projectID string, pc ProjectCreate) (*Project, *Response, error) {
+func (ps *projectService) Modify(ctx context.Context, projectID string, pm *ProjectModify) (*Project, *Response, error) {
 	p := new(Project)
 	resp, err := ps.Do(
 		ctx,
 		headers.Method("PATCH"),
 		url.AddPath("/"+projectID),
-		body.JSON(pc),
+		body.JSON(pm),
 		responsebody.JSON(p),
 	)
 	return p, resp, err
@@ -181,6 +294,34 @@ func (ps *projectService) AddMember(ctx context.Context, projectID string, membe
 	return m, resp, err
 }
 
+func (ps *projectService) AddCollaborator(ctx context.Context, projectID, usernameOrEmail string, permissions Permissions) (*Member, *Response, error) {
+	if strings.Contains(usernameOrEmail, "@") {
+		return ps.AddMember(ctx, projectID, NewEmailMember(usernameOrEmail, permissions))
+	}
+	user, resp, err := ps.users.User(ctx, usernameOrEmail)
+	if err != nil {
+		return nil, resp, err
+	}
+	username := user.Username
+	if username == "" {
+		username = usernameOrEmail
+	}
+	return ps.AddMember(ctx, projectID, &Member{Username: &username, Permissions: &permissions})
+}
+
+func (ps *projectService) Invitations(ctx context.Context, projectID string, opt *ListOptions) ([]*Invitation, *Response, error) {
+	var i []*Invitation
+	resp, err := ps.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/:projectID/invitations"),
+		url.Param("projectID", projectID),
+		listOptions(opt),
+		pageResponse(&i),
+	)
+	return i, resp, err
+}
+
 func (ps *projectService) RemoveMember(ctx context.Context, projectID, username string) (*Response, error) {
 	return ps.Do(
 		ctx,
@@ -0,0 +1,121 @@
+package sevenbridges
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTransferNotReturned is reported by Wait for import or export that
+// server did not return when waited jobs were fetched.
+var ErrTransferNotReturned = errors.New("sevenbridges: job missing from bulk response")
+
+// TransferState is state of import or export job.
+type TransferState string
+
+const (
+	// TransferPending is state of job waiting to be started.
+	TransferPending TransferState = "PENDING"
+	// TransferRunning is state of job that is in progress.
+	TransferRunning TransferState = "RUNNING"
+	// TransferCompleted is state of successfully finished job.
+	TransferCompleted TransferState = "COMPLETED"
+	// TransferFailed is state of job that failed.
+	TransferFailed TransferState = "FAILED"
+)
+
+// IsTerminal returns true if job in this state has finished and its state
+// will not change anymore.
+func (s TransferState) IsTerminal() bool {
+	return s == TransferCompleted || s == TransferFailed
+}
+
+const (
+	// bulkTransferLimit is maximal number of imports or exports that can be
+	// fetched or created in single bulk request.
+	bulkTransferLimit = 100
+	// minTransferPollInterval is time between first polls of job states.
+	minTransferPollInterval = 2 * time.Second
+)
+
+// transferStatus is state of single job or error that occurred while
+// fetching it.
+type transferStatus struct {
+	State TransferState
+	Err   error
+}
+
+// transferStatesFunc fetches jobs with provided IDs and returns their
+// statuses, mapped by job ID. Jobs that were not returned by server should
+// be left out.
+type transferStatesFunc func(ctx context.Context, ids []string) (map[string]*transferStatus, *Response, error)
+
+// waitForTransfers polls states of jobs with provided IDs until all of them
+// are finished. States are fetched in batches with provided function, which
+// is responsible for keeping fetched jobs. Job that could not be fetched
+// (e.g. because it does not exist) is not polled anymore and its error is
+// returned in map of errors by job ID, while other jobs are still waited
+// for. ErrTransferNotReturned is recorded for jobs that server left out of
+// response. Polling stops with error if jobs could not be fetched several
+// times in a row.
+func waitForTransfers(ctx context.Context, ids []string, states transferStatesFunc) (map[string]error, error) {
+	errs := map[string]error{}
+	pending := ids
+	interval := minTransferPollInterval
+	failures := 0
+	for len(pending) > 0 {
+		var (
+			remaining []string
+			rate      *Rate
+			failed    bool
+		)
+		for start := 0; start < len(pending); start += bulkTransferLimit {
+			batch := pending[start:intMin(start+bulkTransferLimit, len(pending))]
+			fetched, resp, err := states(ctx, batch)
+			if r := responseRate(resp, err); r != nil {
+				rate = r
+			}
+			if ctx.Err() != nil {
+				return errs, ctx.Err()
+			}
+			if err != nil {
+				failures++
+				if failures >= maxWatchErrors {
+					return errs, err
+				}
+				failed = true
+				remaining = append(remaining, batch...)
+				continue
+			}
+			for _, id := range batch {
+				status, ok := fetched[id]
+				switch {
+				case !ok:
+					errs[id] = ErrTransferNotReturned
+				case status.Err != nil:
+					errs[id] = status.Err
+				case !status.State.IsTerminal():
+					remaining = append(remaining, id)
+				}
+			}
+		}
+		if !failed {
+			failures = 0
+		}
+		pending = remaining
+		if len(pending) == 0 {
+			return errs, nil
+		}
+
+		requests := (len(pending) + bulkTransferLimit - 1) / bulkTransferLimit
+		select {
+		case <-time.After(pollDelay(interval, rate, requests)):
+		case <-ctx.Done():
+			return errs, ctx.Err()
+		}
+		if interval = interval * 3 / 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,142 @@
+package sevenbridges_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+// fakeBulkServer returns server for bulk get of imports and exports that
+// responds with items returned by provided function for requested IDs.
+func fakeBulkServer(items func(ids []string) []interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ImportIDs []string `json:"import_ids"`
+			ExportIDs []string `json:"export_ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": items(append(body.ImportIDs, body.ExportIDs...)),
+		})
+	}))
+}
+
+func transferItem(id string, state sevenbridges.TransferState) interface{} {
+	return map[string]interface{}{"resource": map[string]interface{}{"id": id, "state": state}}
+}
+
+func TestImportWaitItemErrors(t *testing.T) {
+	// response is out of order and i2 does not exist
+	server := fakeBulkServer(func(ids []string) []interface{} {
+		return []interface{}{
+			transferItem("i3", sevenbridges.TransferFailed),
+			map[string]interface{}{"error": map[string]interface{}{"status": 404, "message": "Not found"}},
+			transferItem("i1", sevenbridges.TransferCompleted),
+		}
+	})
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results, err := client.Import.Wait(ctx, "i1", "i2", "i3")
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if r := results[0]; r.ID != "i1" || r.Err != nil || r.Import.State != sevenbridges.TransferCompleted {
+		t.Errorf("Unexpected result for i1: %+v", r)
+	}
+	if r := results[1]; r.ID != "i2" || r.Import != nil {
+		t.Errorf("Unexpected result for i2: %+v", r)
+	} else if info, ok := r.Err.(*sevenbridges.ErrorInfo); !ok || info.Status != 404 {
+		t.Errorf("Expected 404 error for i2, got %v", r.Err)
+	}
+	if r := results[2]; r.ID != "i3" || r.Err != nil || r.Import.State != sevenbridges.TransferFailed {
+		t.Errorf("Unexpected result for i3: %+v", r)
+	}
+}
+
+func TestExportWaitMissingItems(t *testing.T) {
+	server := fakeBulkServer(func(ids []string) []interface{} {
+		// e2 is left out of response, so errors can not be matched either
+		return []interface{}{
+			transferItem("e1", sevenbridges.TransferCompleted),
+			map[string]interface{}{"error": map[string]interface{}{"status": 404}},
+		}
+	})
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results, err := client.Export.Wait(ctx, "e1", "e2", "e3")
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if r := results[0]; r.Err != nil || r.Export == nil || r.Export.ID != "e1" {
+		t.Errorf("Unexpected result for e1: %+v", r)
+	}
+	for _, r := range results[1:] {
+		if r.Err != sevenbridges.ErrTransferNotReturned {
+			t.Errorf("Expected ErrTransferNotReturned for %s, got %v", r.ID, r.Err)
+		}
+	}
+}
+
+func TestImportWaitBatches(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []int
+		polled   = map[string]int{}
+	)
+	server := fakeBulkServer(func(ids []string) []interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, len(ids))
+		var items []interface{}
+		for _, id := range ids {
+			polled[id]++
+			state := sevenbridges.TransferCompleted
+			// first import is still running on first poll
+			if id == "i0" && polled[id] == 1 {
+				state = sevenbridges.TransferRunning
+			}
+			items = append(items, transferItem(id, state))
+		}
+		return items
+	})
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = "i" + strconv.Itoa(i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := client.Import.Wait(ctx, ids...)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	for i, r := range results {
+		if r.ID != ids[i] || r.Err != nil || r.Import.State != sevenbridges.TransferCompleted {
+			t.Errorf("Unexpected result for %s: %+v", ids[i], r)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if expected := []int{100, 100, 50, 1}; !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected batches %v, got %v", expected, requests)
+	}
+}
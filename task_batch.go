@@ -0,0 +1,107 @@
+package sevenbridges
+
+import (
+	"context"
+	"strings"
+)
+
+// BatchType defines how batch task is split into child tasks.
+type BatchType string
+
+const (
+	// BatchItem creates one child task for every file in batch input.
+	BatchItem BatchType = "ITEM"
+	// BatchCriteria groups files in batch input by metadata criteria and
+	// creates one child task for every group.
+	BatchCriteria BatchType = "CRITERIA"
+)
+
+// Metadata fields commonly used as batch criteria.
+const (
+	BatchSampleID       = "metadata.sample_id"
+	BatchLibraryID      = "metadata.library_id"
+	BatchPlatformUnitID = "metadata.platform_unit_id"
+	BatchFileSegment    = "metadata.file_segment_number"
+)
+
+// BatchBy holds information on how batch task is split into child tasks.
+type BatchBy struct {
+	Type     BatchType `json:"type"`
+	Criteria []string  `json:"criteria,omitempty"`
+}
+
+// BatchByItem returns BatchBy that creates one child task per file.
+func BatchByItem() *BatchBy {
+	return &BatchBy{Type: BatchItem}
+}
+
+// BatchByCriteria returns BatchBy that creates one child task per group of
+// files with same values of provided metadata fields (e.g. BatchSampleID).
+// Fields without "metadata." prefix are prefixed automatically.
+func BatchByCriteria(fields ...string) *BatchBy {
+	criteria := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "metadata.") {
+			f = "metadata." + f
+		}
+		criteria = append(criteria, f)
+	}
+	return &BatchBy{Type: BatchCriteria, Criteria: criteria}
+}
+
+// BatchSummary holds number of child tasks of a batch task in each status.
+type BatchSummary struct {
+	Total     int
+	Draft     int
+	Queued    int
+	Running   int
+	Completed int
+	Aborted   int
+	Failed    int
+}
+
+// IsFinished returns true if all child tasks of a batch have finished.
+func (bs *BatchSummary) IsFinished() bool {
+	return bs.Completed+bs.Aborted+bs.Failed == bs.Total
+}
+
+func (ts *taskService) Children(ctx context.Context, parentID string, opt *ListOptions) ([]*Task, *Response, error) {
+	tlo := &TaskListOptions{Parent: parentID}
+	if opt != nil {
+		tlo.ListOptions = *opt
+	}
+	return ts.List(ctx, tlo)
+}
+
+func (ts *taskService) BatchStatus(ctx context.Context, parentID string) (*BatchSummary, error) {
+	summary := new(BatchSummary)
+	opt := &ListOptions{Fields: []string{"id", "status"}}
+	for {
+		tasks, resp, err := ts.Children(ctx, parentID, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			summary.Total++
+			switch t.Status {
+			case TaskDraft:
+				summary.Draft++
+			case TaskQueued:
+				summary.Queued++
+			case TaskRunning:
+				summary.Running++
+			case TaskCompleted:
+				summary.Completed++
+			case TaskAborted:
+				summary.Aborted++
+			case TaskFailed:
+				summary.Failed++
+			}
+		}
+		if !resp.HasNextPage() {
+			return summary, nil
+		}
+		opt = resp.NextPage()
+		opt.Fields = []string{"id", "status"}
+	}
+}
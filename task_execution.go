@@ -0,0 +1,123 @@
+package sevenbridges
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+)
+
+// ExecutionDetails holds information about execution of a task.
+type ExecutionDetails struct {
+	Href      string     `json:"href"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   time.Time  `json:"end_time"`
+	Status    TaskStatus `json:"status"`
+	Message   string     `json:"message"`
+	Jobs      []*Job     `json:"jobs"`
+}
+
+// FailedJobs returns jobs whose execution failed.
+func (ed *ExecutionDetails) FailedJobs() []*Job {
+	var failed []*Job
+	for _, j := range ed.Jobs {
+		if j.Status == TaskFailed {
+			failed = append(failed, j)
+		}
+	}
+	return failed
+}
+
+// Job holds information about single job (execution of single tool) within
+// task execution.
+type Job struct {
+	Name        string       `json:"name"`
+	StartTime   time.Time    `json:"start_time"`
+	EndTime     time.Time    `json:"end_time"`
+	Status      TaskStatus   `json:"status"`
+	CommandLine string       `json:"command_line"`
+	Retried     bool         `json:"retried"`
+	Instance    *JobInstance `json:"instance"`
+	// Logs maps names of log files (e.g. "cmd.log", "job.err.log") to
+	// references to those files.
+	Logs map[string]string `json:"logs"`
+}
+
+// JobInstance holds information about computation instance job was
+// executed on.
+type JobInstance struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Type     string `json:"type"`
+}
+
+// LogNames returns sorted names of all logs available for the job.
+func (j *Job) LogNames() []string {
+	names := make([]string, 0, len(j.Logs))
+	for name := range j.Logs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LogFileID returns ID of file that holds log with provided name. Empty
+// string is returned if there is no such log.
+func (j *Job) LogFileID(name string) string {
+	ref, ok := j.Logs[name]
+	if !ok {
+		return ""
+	}
+	// logs are referenced by URL to file resource or its download info
+	if i := strings.Index(ref, "/files/"); i >= 0 {
+		ref = ref[i+len("/files/"):]
+		if end := strings.Index(ref, "/"); end >= 0 {
+			ref = ref[:end]
+		}
+	}
+	return ref
+}
+
+// stderrLogName returns name of log that holds standard error of the job.
+func (j *Job) stderrLogName() string {
+	for _, name := range j.LogNames() {
+		if strings.Contains(name, "stderr") || strings.HasSuffix(name, ".err.log") || strings.HasSuffix(name, ".stderr") {
+			return name
+		}
+	}
+	return ""
+}
+
+func (ts *taskService) ExecutionDetails(ctx context.Context, taskID string) (*ExecutionDetails, *Response, error) {
+	ed := new(ExecutionDetails)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/:taskID/execution_details"),
+		url.Param("taskID", taskID),
+		responsebody.JSON(ed),
+	)
+	return ed, resp, err
+}
+
+func (ts *taskService) JobLog(ctx context.Context, job *Job, name string, w io.Writer) error {
+	fileID := job.LogFileID(name)
+	if fileID == "" {
+		return fmt.Errorf("sevenbridges: job %s has no log %q", job.Name, name)
+	}
+	return ts.download.DownloadWriter(ctx, fileID, w)
+}
+
+func (ts *taskService) JobStderr(ctx context.Context, job *Job, w io.Writer) error {
+	name := job.stderrLogName()
+	if name == "" {
+		return fmt.Errorf("sevenbridges: job %s has no standard error log", job.Name)
+	}
+	return ts.JobLog(ctx, job, name, w)
+}
@@ -0,0 +1,256 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// InputFile is task input value that references file on SevenBridges
+// platform.
+type InputFile struct {
+	Class string `json:"class"`
+	Path  string `json:"path"`
+	Name  string `json:"name,omitempty"`
+}
+
+// NewInputFile returns task input value that references file with provided ID.
+func NewInputFile(fileID string) *InputFile {
+	return &InputFile{Class: CWLFile, Path: fileID}
+}
+
+// InputBuilder builds task inputs with properly formatted values.
+type InputBuilder struct {
+	inputs TaskInputs
+}
+
+// NewInputBuilder returns new empty InputBuilder.
+func NewInputBuilder() *InputBuilder {
+	return &InputBuilder{TaskInputs{}}
+}
+
+// Set sets value of input with provided ID as is.
+func (ib *InputBuilder) Set(id string, value interface{}) *InputBuilder {
+	ib.inputs[id] = value
+	return ib
+}
+
+// File sets input with provided ID to file with provided ID.
+func (ib *InputBuilder) File(id, fileID string) *InputBuilder {
+	return ib.Set(id, NewInputFile(fileID))
+}
+
+// Files sets input with provided ID to list of files with provided IDs.
+func (ib *InputBuilder) Files(id string, fileIDs ...string) *InputBuilder {
+	files := make([]*InputFile, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		files = append(files, NewInputFile(fileID))
+	}
+	return ib.Set(id, files)
+}
+
+// String sets input with provided ID to string value.
+func (ib *InputBuilder) String(id, value string) *InputBuilder {
+	return ib.Set(id, value)
+}
+
+// Enum sets input with provided ID to one of enum symbols.
+func (ib *InputBuilder) Enum(id, symbol string) *InputBuilder {
+	return ib.Set(id, symbol)
+}
+
+// Int sets input with provided ID to integer value.
+func (ib *InputBuilder) Int(id string, value int) *InputBuilder {
+	return ib.Set(id, value)
+}
+
+// Float sets input with provided ID to floating point value.
+func (ib *InputBuilder) Float(id string, value float64) *InputBuilder {
+	return ib.Set(id, value)
+}
+
+// Bool sets input with provided ID to boolean value.
+func (ib *InputBuilder) Bool(id string, value bool) *InputBuilder {
+	return ib.Set(id, value)
+}
+
+// Record sets input with provided ID to record with provided fields. Fields
+// can be built with another InputBuilder.
+func (ib *InputBuilder) Record(id string, fields TaskInputs) *InputBuilder {
+	return ib.Set(id, fields)
+}
+
+// Build returns built task inputs.
+func (ib *InputBuilder) Build() TaskInputs {
+	return ib.inputs
+}
+
+// InputError describes single problem with task input.
+type InputError struct {
+	Input   string
+	Message string
+}
+
+// ValidationError is returned when task inputs do not match inputs of the app.
+type ValidationError struct {
+	Errors []*InputError
+}
+
+// Implementation of error interface
+func (ve *ValidationError) Error() string {
+	problems := make([]string, 0, len(ve.Errors))
+	for _, e := range ve.Errors {
+		problems = append(problems, fmt.Sprintf("%s: %s", e.Input, e.Message))
+	}
+	return fmt.Sprintf("sevenbridges: invalid task inputs [%s]", strings.Join(problems, "; "))
+}
+
+// ValidateInputs checks provided task inputs against inputs of provided
+// process. It reports missing required inputs, unknown inputs and values
+// that do not match input type. If problems are found, ValidationError is
+// returned.
+func ValidateInputs(p Process, inputs TaskInputs) error {
+	// normalize values to what they look like when sent to server
+	raw, err := json.Marshal(inputs)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+
+	ve := new(ValidationError)
+	params := p.Common().Inputs
+	for _, param := range params {
+		value, ok := values[param.ID]
+		if !ok || value == nil {
+			if param.Type != nil && !param.Type.Optional && param.Default == nil {
+				ve.Errors = append(ve.Errors, &InputError{param.ID, "missing required input"})
+			}
+			continue
+		}
+		if param.Type == nil {
+			continue
+		}
+		if msg := checkInputValue(param.Type, value); msg != "" {
+			ve.Errors = append(ve.Errors, &InputError{param.ID, msg})
+		}
+	}
+
+	var unknown []string
+	for id := range values {
+		if params.ByID(id) == nil {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		ve.Errors = append(ve.Errors, &InputError{id, "unknown input"})
+	}
+
+	if len(ve.Errors) > 0 {
+		return ve
+	}
+	return nil
+}
+
+func (ts *taskService) ValidateInputs(ctx context.Context, appID string, inputs TaskInputs) error {
+	app, _, err := ts.apps.ByID(ctx, appID, LatestRevision)
+	if err != nil {
+		return err
+	}
+	p, err := app.CWL()
+	if err != nil {
+		return err
+	}
+	return ValidateInputs(p, inputs)
+}
+
+// checkInputValue checks if JSON decoded value matches provided type and
+// returns description of the problem if it does not.
+func checkInputValue(t *CWLType, value interface{}) string {
+	if value == nil {
+		if t.Optional || t.Type == CWLNull {
+			return ""
+		}
+		return "value is required"
+	}
+
+	switch t.Type {
+	case CWLFile, CWLDirectory:
+		obj, ok := value.(map[string]interface{})
+		if !ok || obj["class"] != t.Type {
+			return fmt.Sprintf("expected %s", t.Type)
+		}
+		if path, _ := obj["path"].(string); path == "" {
+			return fmt.Sprintf("%s path is missing", t.Type)
+		}
+	case CWLArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Sprintf("expected %s", t)
+		}
+		if t.Items == nil {
+			return ""
+		}
+		for i, item := range items {
+			if msg := checkInputValue(t.Items, item); msg != "" {
+				return fmt.Sprintf("item %d: %s", i, msg)
+			}
+		}
+	case CWLInt, CWLLong:
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Sprintf("expected %s", t.Type)
+		}
+	case CWLFloat, CWLDouble:
+		if _, ok := value.(float64); !ok {
+			return fmt.Sprintf("expected %s", t.Type)
+		}
+	case CWLString:
+		if _, ok := value.(string); !ok {
+			return "expected string"
+		}
+	case CWLBoolean:
+		if _, ok := value.(bool); !ok {
+			return "expected boolean"
+		}
+	case CWLEnum:
+		symbol, ok := value.(string)
+		if !ok {
+			return "expected enum symbol"
+		}
+		for _, s := range t.Symbols {
+			if s == symbol {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of [%s]", symbol, strings.Join(t.Symbols, ", "))
+	case CWLRecord:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return "expected record"
+		}
+		for _, f := range t.Fields {
+			if f.Type == nil {
+				continue
+			}
+			if msg := checkInputValue(f.Type, fields[f.ID]); msg != "" {
+				return fmt.Sprintf("field %s: %s", f.ID, msg)
+			}
+		}
+	case CWLUnion:
+		for _, alternative := range t.Union {
+			if checkInputValue(alternative, value) == "" {
+				return ""
+			}
+		}
+		return fmt.Sprintf("expected %s", t)
+	}
+	// other types (e.g. "Any" or named types) are not checked
+	return ""
+}
@@ -0,0 +1,46 @@
+package sevenbridges_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+func TestValidateInputs(t *testing.T) {
+	p, err := sevenbridges.ParseCWL(json.RawMessage(testTool))
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+
+	valid := sevenbridges.NewInputBuilder().
+		Files("reads", "file1", "file2").
+		File("reference", "file3").
+		Int("threads", 8).
+		Enum("mode", "fast").
+		Build()
+	if err := sevenbridges.ValidateInputs(p, valid); err != nil {
+		t.Error("Expected valid inputs, got: ", err)
+	}
+
+	invalid := sevenbridges.NewInputBuilder().
+		File("reads", "file1").
+		Float("threads", 1.5).
+		Enum("mode", "medium").
+		String("unknown", "value").
+		Build()
+	err = sevenbridges.ValidateInputs(p, invalid)
+	ve, ok := err.(*sevenbridges.ValidationError)
+	if !ok {
+		t.Fatalf("Expected ValidationError, got %#v", err)
+	}
+	expected := []string{"reads", "reference", "threads", "mode", "unknown"}
+	if len(ve.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %s", len(expected), ve)
+	}
+	for i, e := range ve.Errors {
+		if e.Input != expected[i] {
+			t.Errorf("Expected error for %s, got %s: %s", expected[i], e.Input, e.Message)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package sevenbridges
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// bulkTaskLimit is maximal number of tasks that can be fetched in single
+	// bulk request.
+	bulkTaskLimit = 100
+	// minPollInterval is time between polls right after status of some of
+	// watched tasks has changed.
+	minPollInterval = 5 * time.Second
+	// maxPollInterval is maximal time between polls when statuses of watched
+	// tasks are not changing.
+	maxPollInterval = 2 * time.Minute
+	// maxWatchErrors is number of consecutive failed polls after which
+	// watching is stopped.
+	maxWatchErrors = 5
+)
+
+// ErrTaskNotReturned is sent by Watch for task that server did not return
+// when watched tasks were fetched.
+var ErrTaskNotReturned = errors.New("sevenbridges: task missing from bulk response")
+
+// TaskEvent is sent by Watch when status of watched task changes or when
+// watching fails.
+type TaskEvent struct {
+	// TaskID is ID of task this event is about. It is empty if tasks could
+	// not be fetched at all.
+	TaskID string
+	// Task is task in its new status.
+	Task *Task
+	// PreviousStatus is status of the task before the change. It is empty
+	// for first event of every task.
+	PreviousStatus TaskStatus
+	// Err is set if task could not be fetched. Task with TaskID is not watched
+	// anymore after error. If TaskID is empty, watching is stopped.
+	Err error
+}
+
+func (ts *taskService) Watch(ctx context.Context, taskIDs ...string) <-chan *TaskEvent {
+	events := make(chan *TaskEvent)
+	go ts.watch(ctx, taskIDs, events)
+	return events
+}
+
+func (ts *taskService) WaitFor(ctx context.Context, taskID string) (*Task, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for event := range ts.Watch(ctx, taskID) {
+		if event.Err != nil {
+			return nil, event.Err
+		}
+		if event.Task.Status.IsTerminal() {
+			return event.Task, nil
+		}
+	}
+	return nil, ctx.Err()
+}
+
+// watch polls tasks with provided IDs until all of them are finished and
+// sends event to provided channel for every status change. Every send is
+// interrupted when context is done.
+func (ts *taskService) watch(ctx context.Context, taskIDs []string, events chan<- *TaskEvent) {
+	defer close(events)
+
+	send := func(event *TaskEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	statuses := make(map[string]TaskStatus, len(taskIDs))
+	var pending []string
+	for _, id := range taskIDs {
+		if _, ok := statuses[id]; !ok {
+			statuses[id] = ""
+			pending = append(pending, id)
+		}
+	}
+
+	interval := minPollInterval
+	failures := 0
+	for len(pending) > 0 {
+		var (
+			remaining []string
+			rate      *Rate
+			changed   bool
+			failed    bool
+		)
+		for start := 0; start < len(pending); start += bulkTaskLimit {
+			batch := pending[start:intMin(start+bulkTaskLimit, len(pending))]
+			results, resp, err := ts.BulkGet(ctx, batch)
+			if r := responseRate(resp, err); r != nil {
+				rate = r
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				failures++
+				if failures >= maxWatchErrors {
+					send(&TaskEvent{Err: err})
+					return
+				}
+				failed = true
+				remaining = append(remaining, batch...)
+				continue
+			}
+			for i, id := range batch {
+				var result *BulkTaskResult
+				if i < len(results) {
+					result = results[i]
+				}
+				if result == nil || (result.Error == nil && result.Resource == nil) {
+					if !send(&TaskEvent{TaskID: id, Err: ErrTaskNotReturned}) {
+						return
+					}
+					continue
+				}
+				if result.Error != nil {
+					if !send(&TaskEvent{TaskID: id, Err: result.Error}) {
+						return
+					}
+					continue
+				}
+				task := result.Resource
+				if previous := statuses[id]; previous != task.Status {
+					changed = true
+					statuses[id] = task.Status
+					if !send(&TaskEvent{TaskID: id, Task: task, PreviousStatus: previous}) {
+						return
+					}
+				}
+				if !task.Status.IsTerminal() {
+					remaining = append(remaining, id)
+				}
+			}
+		}
+		if !failed {
+			failures = 0
+		}
+		pending = remaining
+		if len(pending) == 0 {
+			return
+		}
+
+		if changed {
+			interval = minPollInterval
+		} else if interval = interval * 3 / 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+		requests := (len(pending) + bulkTaskLimit - 1) / bulkTaskLimit
+		select {
+		case <-time.After(pollDelay(interval, rate, requests)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pollDelay returns time to wait before next poll. If rate limit does not
+// allow provided number of requests, delay is extended until rate limit
+// is reset.
+func pollDelay(interval time.Duration, rate *Rate, requests int) time.Duration {
+	if rate == nil || rate.Limit == 0 || rate.Remaining >= requests || rate.Reset.IsZero() {
+		return interval
+	}
+	if untilReset := time.Until(rate.Reset.Time); untilReset > interval {
+		return untilReset
+	}
+	return interval
+}
+
+// responseRate returns rate limit information from provided response or, if
+// request failed, from error. Rate limit of rejected request (429 status) is
+// considered exhausted until reset.
+func responseRate(resp *Response, err error) *Rate {
+	if resp != nil {
+		return resp.Rate
+	}
+	httpErr, ok := err.(*HTTPError)
+	if !ok || httpErr.Rate == nil {
+		return nil
+	}
+	rate := *httpErr.Rate
+	if httpErr.StatusCode == http.StatusTooManyRequests {
+		// limit header might be missing in rejected response, but it is
+		// known that no requests remain
+		if rate.Limit == 0 {
+			rate.Limit = 1
+		}
+		rate.Remaining = 0
+	}
+	return &rate
+}
@@ -0,0 +1,111 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// t3 is left out of response
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"resource": map[string]interface{}{"id": "t1", "status": "COMPLETED"}},
+			map[string]interface{}{"error": map[string]interface{}{"status": 404, "message": "Not found"}},
+		}})
+	}))
+	defer server.Close()
+	tasks := newTaskService(newClient(server.URL, "token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var events []*TaskEvent
+	for event := range tasks.Watch(ctx, "t1", "t2", "t3") {
+		events = append(events, event)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if e := events[0]; e.TaskID != "t1" || e.Task == nil || e.Task.Status != TaskCompleted {
+		t.Errorf("Unexpected event for t1: %+v", e)
+	}
+	if e := events[1]; e.TaskID != "t2" || e.Err == nil {
+		t.Errorf("Expected error event for t2, got %+v", e)
+	}
+	if e := events[2]; e.TaskID != "t3" || e.Err != ErrTaskNotReturned {
+		t.Errorf("Expected ErrTaskNotReturned for t3, got %+v", e)
+	}
+}
+
+func TestWatchStopsWhenContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"resource": map[string]interface{}{"id": "t1", "status": "RUNNING"}},
+		}})
+	}))
+	defer server.Close()
+	tasks := newTaskService(newClient(server.URL, "token"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := tasks.Watch(ctx, "t1")
+	// consumer stops reading after first event
+	<-events
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("Expected no more events after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Error("Watch did not stop after context has been cancelled")
+	}
+}
+
+func TestPollDelay(t *testing.T) {
+	interval := 10 * time.Second
+	reset := Timestamp{time.Now().Add(time.Minute)}
+	for _, tc := range []struct {
+		name     string
+		rate     *Rate
+		requests int
+		min, max time.Duration
+	}{
+		{"no rate", nil, 1, interval, interval},
+		{"unlimited", &Rate{}, 1, interval, interval},
+		{"enough remaining", &Rate{Limit: 100, Remaining: 5, Reset: reset}, 5, interval, interval},
+		{"unknown reset", &Rate{Limit: 100, Remaining: 0}, 1, interval, interval},
+		{"rate limited", &Rate{Limit: 100, Remaining: 1, Reset: reset}, 2, 50 * time.Second, time.Minute},
+		{"reset before interval", &Rate{Limit: 100, Remaining: 0, Reset: Timestamp{time.Now().Add(time.Second)}}, 1, interval, interval},
+	} {
+		if d := pollDelay(interval, tc.rate, tc.requests); d < tc.min || d > tc.max {
+			t.Errorf("%s: expected delay between %s and %s, got %s", tc.name, tc.min, tc.max, d)
+		}
+	}
+}
+
+func TestPollDelayAfterRateLimitError(t *testing.T) {
+	reset := time.Now().Add(time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateReset, strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status": 429, "message": "Too many requests"}`))
+	}))
+	defer server.Close()
+	tasks := newTaskService(newClient(server.URL, "token"))
+
+	_, resp, err := tasks.BulkGet(context.Background(), []string{"t1"})
+	if err == nil {
+		t.Fatal("Expected error for rate limited request")
+	}
+	rate := responseRate(resp, err)
+	if rate == nil {
+		t.Fatal("Expected rate from error response")
+	}
+	if d := pollDelay(10*time.Second, rate, 1); d < 50*time.Second {
+		t.Errorf("Expected to wait until rate limit reset, got %s", d)
+	}
+}
@@ -0,0 +1,339 @@
+package sevenbridges
+
+import (
+	"context"
+	"io"
+	"time"
+
+	c "github.com/delicb/cliware"
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/query"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// TaskStatus is status of task on SevenBridges platform.
+type TaskStatus string
+
+const (
+	// TaskDraft is status of task that has been created, but not run.
+	TaskDraft TaskStatus = "DRAFT"
+	// TaskQueued is status of task waiting for execution.
+	TaskQueued TaskStatus = "QUEUED"
+	// TaskRunning is status of task that is being executed.
+	TaskRunning TaskStatus = "RUNNING"
+	// TaskCompleted is status of successfully finished task.
+	TaskCompleted TaskStatus = "COMPLETED"
+	// TaskAborted is status of task aborted by user.
+	TaskAborted TaskStatus = "ABORTED"
+	// TaskFailed is status of task whose execution failed.
+	TaskFailed TaskStatus = "FAILED"
+)
+
+// IsTerminal returns true if task with this status has finished and its
+// status will not change anymore.
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskCompleted || s == TaskAborted || s == TaskFailed
+}
+
+// TaskInputs holds inputs or outputs of a task, mapped by their IDs.
+type TaskInputs map[string]interface{}
+
+// Task holds information about single task (execution of an app) on
+// SevenBridges platform.
+type Task struct {
+	Href                      string             `json:"href"`
+	ID                        string             `json:"id"`
+	Name                      string             `json:"name"`
+	Description               string             `json:"description"`
+	Status                    TaskStatus         `json:"status"`
+	Project                   string             `json:"project"`
+	App                       string             `json:"app"`
+	Type                      string             `json:"type"`
+	CreatedBy                 string             `json:"created_by"`
+	ExecutedBy                string             `json:"executed_by"`
+	CreatedTime               time.Time          `json:"created_time"`
+	StartTime                 time.Time          `json:"start_time"`
+	EndTime                   time.Time          `json:"end_time"`
+	Batch                     bool               `json:"batch"`
+	BatchInput                string             `json:"batch_input"`
+	BatchBy                   *BatchBy           `json:"batch_by"`
+	Parent                    string             `json:"parent"`
+	UseInterruptibleInstances bool               `json:"use_interruptible_instances"`
+	Price                     *TaskPrice         `json:"price"`
+	ExecutionSettings         *ExecutionSettings `json:"execution_settings"`
+	ExecutionStatus           *ExecutionStatus   `json:"execution_status"`
+	Inputs                    TaskInputs         `json:"inputs"`
+	Outputs                   TaskInputs         `json:"outputs"`
+	Errors                    []*ErrorInfo       `json:"errors"`
+	Warnings                  []*ErrorInfo       `json:"warnings"`
+}
+
+// TaskPrice holds information about price of task execution.
+type TaskPrice struct {
+	Currency  string `json:"currency"`
+	Amount    string `json:"amount"`
+	Breakdown struct {
+		Storage      string `json:"storage"`
+		Computation  string `json:"computation"`
+		DataTransfer string `json:"data_transfer"`
+	} `json:"breakdown"`
+}
+
+// ExecutionSettings holds settings of task execution.
+type ExecutionSettings struct {
+	InstanceType         string `json:"instance_type,omitempty"`
+	MaxParallelInstances int    `json:"max_parallel_instances,omitempty"`
+	UseMemoization       *bool  `json:"use_memoization,omitempty"`
+	UseElasticDisk       *bool  `json:"use_elastic_disk,omitempty"`
+}
+
+// ExecutionStatus holds information about progress of task execution.
+type ExecutionStatus struct {
+	Message           string `json:"message"`
+	Queued            int    `json:"queued"`
+	Running           int    `json:"running"`
+	Completed         int    `json:"completed"`
+	Failed            int    `json:"failed"`
+	Aborted           int    `json:"aborted"`
+	StepsCompleted    int    `json:"steps_completed"`
+	Duration          int64  `json:"duration"`
+	ExecutionDuration int64  `json:"execution_duration"`
+	QueuedDuration    int64  `json:"queued_duration"`
+	RunningDuration   int64  `json:"running_duration"`
+}
+
+// BulkTaskResult holds result of fetching single task in bulk request.
+// Exactly one of Resource and Error is populated.
+type BulkTaskResult struct {
+	Resource *Task      `json:"resource"`
+	Error    *ErrorInfo `json:"error"`
+}
+
+// TaskListOptions specifies optional filters for listing tasks.
+type TaskListOptions struct {
+	ListOptions
+	Project     string     `url:"project,omitempty"`
+	Status      TaskStatus `url:"status,omitempty"`
+	Parent      string     `url:"parent,omitempty"`
+	CreatedFrom time.Time  `url:"created_from,omitempty"`
+	CreatedTo   time.Time  `url:"created_to,omitempty"`
+}
+
+// TaskCreate is structure that defines body required for creating new task.
+type TaskCreate struct {
+	Name                      string             `json:"name,omitempty"`
+	Description               string             `json:"description,omitempty"`
+	Project                   string             `json:"project"`
+	App                       string             `json:"app"`
+	Inputs                    TaskInputs         `json:"inputs,omitempty"`
+	ExecutionSettings         *ExecutionSettings `json:"execution_settings,omitempty"`
+	UseInterruptibleInstances *bool              `json:"use_interruptible_instances,omitempty"`
+	// BatchInput is ID of input over which batch task is created. If it is
+	// provided, BatchBy has to be provided as well.
+	BatchInput string   `json:"batch_input,omitempty"`
+	BatchBy    *BatchBy `json:"batch_by,omitempty"`
+	// Run is flag marking if task should be run immediately after creation.
+	// Otherwise, task is created as draft.
+	Run bool `json:"-"`
+}
+
+// TaskModify is structure that defines body for modifying draft task. Only
+// provided fields are modified.
+type TaskModify struct {
+	Name              string             `json:"name,omitempty"`
+	Description       string             `json:"description,omitempty"`
+	Inputs            TaskInputs         `json:"inputs,omitempty"`
+	ExecutionSettings *ExecutionSettings `json:"execution_settings,omitempty"`
+}
+
+// TaskService is interface that defines task related operations available
+// on SevenBridges platform.
+type TaskService interface {
+	// List returns tasks (single page) that match provided options.
+	List(ctx context.Context, opt *TaskListOptions) ([]*Task, *Response, error)
+	// ByID returns task with provided ID.
+	ByID(ctx context.Context, taskID string) (*Task, *Response, error)
+	// Create creates new task and, if requested, runs it.
+	Create(ctx context.Context, tc TaskCreate) (*Task, *Response, error)
+	// Modify edits draft task with provided ID.
+	Modify(ctx context.Context, taskID string, tm TaskModify) (*Task, *Response, error)
+	// UpdateInputs updates inputs of draft task. Inputs that are not
+	// provided are left unchanged.
+	UpdateInputs(ctx context.Context, taskID string, inputs TaskInputs) (TaskInputs, *Response, error)
+	// Run starts execution of draft task.
+	Run(ctx context.Context, taskID string) (*Task, *Response, error)
+	// Abort stops execution of running task.
+	Abort(ctx context.Context, taskID string) (*Task, *Response, error)
+	// Clone creates new draft task with same app and inputs as task with
+	// provided ID.
+	Clone(ctx context.Context, taskID string) (*Task, *Response, error)
+	// Delete removes task with provided ID.
+	Delete(ctx context.Context, taskID string) (*Response, error)
+	// BulkGet returns tasks with provided IDs in single request. Results are
+	// in the same order as provided IDs. At most 100 tasks can be fetched
+	// at once.
+	BulkGet(ctx context.Context, taskIDs []string) ([]*BulkTaskResult, *Response, error)
+	// Watch polls tasks with provided IDs and sends event to returned channel
+	// every time status of any of them changes. Channel is closed when all
+	// tasks finish, when context is done or when tasks could not be fetched
+	// several times in a row. Caller has to read channel until it is closed
+	// or cancel context, otherwise polling goroutine is never stopped.
+	Watch(ctx context.Context, taskIDs ...string) <-chan *TaskEvent
+	// WaitFor blocks until task with provided ID finishes and returns it.
+	WaitFor(ctx context.Context, taskID string) (*Task, error)
+	// Children returns child tasks (single page) of batch task with
+	// provided ID.
+	Children(ctx context.Context, parentID string, opt *ListOptions) ([]*Task, *Response, error)
+	// BatchStatus goes through all child tasks of batch task with provided
+	// ID and returns number of child tasks in each status.
+	BatchStatus(ctx context.Context, parentID string) (*BatchSummary, error)
+	// ExecutionDetails returns details about execution of task with provided
+	// ID, including status of each of its jobs.
+	ExecutionDetails(ctx context.Context, taskID string) (*ExecutionDetails, *Response, error)
+	// JobLog downloads log with provided name of a job and writes it to w.
+	JobLog(ctx context.Context, job *Job, name string, w io.Writer) error
+	// JobStderr downloads standard error log of a job and writes it to w.
+	JobStderr(ctx context.Context, job *Job, w io.Writer) error
+	// ValidateInputs checks provided task inputs against inputs of app with
+	// provided ID, without creating a task. ValidationError is returned if
+	// inputs are not valid.
+	ValidateInputs(ctx context.Context, appID string, inputs TaskInputs) error
+	// Outdated returns all tasks in project with provided ID that were run
+	// on app revision older than latest revision of the app. Draft tasks are
+	// not checked. Tasks whose app could not be fetched are returned with
+	// Err set, instead of failing whole check.
+	Outdated(ctx context.Context, projectID string) ([]*OutdatedTask, error)
+}
+
+type taskService struct {
+	*service
+	bulk     *service
+	download DownloadService
+	apps     AppService
+}
+
+func newTaskService(client gwc.Doer) TaskService {
+	service := newService(client)
+	service.Use(url.AddPath("/tasks"))
+	bulk := newService(client)
+	bulk.Use(url.AddPath("/bulk/tasks"))
+	return &taskService{service, bulk, newDownloadService(client), newAppService(client)}
+}
+
+// just make sure at compile time that taskService implements TaskService
+var _ TaskService = new(taskService)
+
+func (ts *taskService) List(ctx context.Context, opt *TaskListOptions) ([]*Task, *Response, error) {
+	var t []*Task
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		queryOptions(opt),
+		pageResponse(&t),
+	)
+	return t, resp, err
+}
+
+func (ts *taskService) ByID(ctx context.Context, taskID string) (*Task, *Response, error) {
+	t := new(Task)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/:taskID"),
+		url.Param("taskID", taskID),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *taskService) Create(ctx context.Context, tc TaskCreate) (*Task, *Response, error) {
+	t := new(Task)
+	middlewares := []c.Middleware{
+		headers.Method("POST"),
+		body.JSON(tc),
+		responsebody.JSON(t),
+	}
+	if tc.Run {
+		middlewares = append(middlewares, query.Add("action", "run"))
+	}
+	resp, err := ts.Do(ctx, middlewares...)
+	return t, resp, err
+}
+
+func (ts *taskService) Modify(ctx context.Context, taskID string, tm TaskModify) (*Task, *Response, error) {
+	t := new(Task)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("PATCH"),
+		url.AddPath("/:taskID"),
+		url.Param("taskID", taskID),
+		body.JSON(tm),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *taskService) UpdateInputs(ctx context.Context, taskID string, inputs TaskInputs) (TaskInputs, *Response, error) {
+	i := TaskInputs{}
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("PATCH"),
+		url.AddPath("/:taskID/inputs"),
+		url.Param("taskID", taskID),
+		body.JSON(inputs),
+		responsebody.JSON(&i),
+	)
+	return i, resp, err
+}
+
+func (ts *taskService) Run(ctx context.Context, taskID string) (*Task, *Response, error) {
+	return ts.action(ctx, taskID, "run")
+}
+
+func (ts *taskService) Abort(ctx context.Context, taskID string) (*Task, *Response, error) {
+	return ts.action(ctx, taskID, "abort")
+}
+
+func (ts *taskService) Clone(ctx context.Context, taskID string) (*Task, *Response, error) {
+	return ts.action(ctx, taskID, "clone")
+}
+
+// action executes provided action on task and returns resulting task.
+func (ts *taskService) action(ctx context.Context, taskID, action string) (*Task, *Response, error) {
+	t := new(Task)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/:taskID/actions/:action"),
+		url.Params(map[string]string{
+			"taskID": taskID,
+			"action": action,
+		}),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *taskService) Delete(ctx context.Context, taskID string) (*Response, error) {
+	return ts.Do(
+		ctx,
+		headers.Method("DELETE"),
+		url.AddPath("/:taskID"),
+		url.Param("taskID", taskID),
+	)
+}
+
+func (ts *taskService) BulkGet(ctx context.Context, taskIDs []string) ([]*BulkTaskResult, *Response, error) {
+	var r []*BulkTaskResult
+	resp, err := ts.bulk.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/get"),
+		body.JSON(map[string][]string{"task_ids": taskIDs}),
+		pageResponse(&r),
+	)
+	return r, resp, err
+}
@@ -0,0 +1,156 @@
+package sevenbridges
+
+import (
+	"context"
+
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/query"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+// Team holds information about team within a division. Teams can be added to
+// projects as members, granting access to all team members at once.
+type Team struct {
+	Href string `json:"href"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TeamCreate is structure that defines body required for creating new team.
+type TeamCreate struct {
+	Name string `json:"name"`
+	// Division is ID of division team is created in.
+	Division string `json:"division"`
+}
+
+// TeamMember is user that is member of a team.
+type TeamMember struct {
+	Href     string `json:"href"`
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// TeamService is interface that defines team related operations available on
+// SevenBridges platform.
+type TeamService interface {
+	// List returns teams (single page) in division with provided ID.
+	List(ctx context.Context, divisionID string, opt *ListOptions) ([]*Team, *Response, error)
+	// ByID returns team with provided ID.
+	ByID(ctx context.Context, teamID string) (*Team, *Response, error)
+	// Create creates new team.
+	Create(ctx context.Context, tc TeamCreate) (*Team, *Response, error)
+	// Rename changes name of team with provided ID.
+	Rename(ctx context.Context, teamID, name string) (*Team, *Response, error)
+	// Delete removes team with provided ID.
+	Delete(ctx context.Context, teamID string) (*Response, error)
+	// Members returns members (single page) of team with provided ID.
+	Members(ctx context.Context, teamID string, opt *ListOptions) ([]*TeamMember, *Response, error)
+	// AddMember adds user with provided username to team with provided ID.
+	AddMember(ctx context.Context, teamID, username string) (*TeamMember, *Response, error)
+	// RemoveMember removes user with provided username from team with
+	// provided ID.
+	RemoveMember(ctx context.Context, teamID, username string) (*Response, error)
+}
+
+type teamService struct {
+	*service
+}
+
+func newTeamService(client gwc.Doer) TeamService {
+	service := newService(client)
+	service.Use(url.AddPath("/teams"))
+	return &teamService{service}
+}
+
+// just make sure at compile time that teamService implements TeamService
+var _ TeamService = new(teamService)
+
+func (ts *teamService) List(ctx context.Context, divisionID string, opt *ListOptions) ([]*Team, *Response, error) {
+	var t []*Team
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		query.Add("division", divisionID),
+		listOptions(opt),
+		pageResponse(&t),
+	)
+	return t, resp, err
+}
+
+func (ts *teamService) ByID(ctx context.Context, teamID string) (*Team, *Response, error) {
+	t := new(Team)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+teamID),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *teamService) Create(ctx context.Context, tc TeamCreate) (*Team, *Response, error) {
+	t := new(Team)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("POST"),
+		body.JSON(tc),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *teamService) Rename(ctx context.Context, teamID, name string) (*Team, *Response, error) {
+	t := new(Team)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("PATCH"),
+		url.AddPath("/"+teamID),
+		body.JSON(map[string]string{"name": name}),
+		responsebody.JSON(t),
+	)
+	return t, resp, err
+}
+
+func (ts *teamService) Delete(ctx context.Context, teamID string) (*Response, error) {
+	return ts.Do(
+		ctx,
+		headers.Method("DELETE"),
+		url.AddPath("/"+teamID),
+	)
+}
+
+func (ts *teamService) Members(ctx context.Context, teamID string, opt *ListOptions) ([]*TeamMember, *Response, error) {
+	var m []*TeamMember
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+teamID+"/members"),
+		listOptions(opt),
+		pageResponse(&m),
+	)
+	return m, resp, err
+}
+
+func (ts *teamService) AddMember(ctx context.Context, teamID, username string) (*TeamMember, *Response, error) {
+	m := new(TeamMember)
+	resp, err := ts.Do(
+		ctx,
+		headers.Method("POST"),
+		url.AddPath("/"+teamID+"/members"),
+		body.JSON(map[string]string{"id": username}),
+		responsebody.JSON(m),
+	)
+	return m, resp, err
+}
+
+func (ts *teamService) RemoveMember(ctx context.Context, teamID, username string) (*Response, error) {
+	return ts.Do(
+		ctx,
+		headers.Method("DELETE"),
+		url.AddPath("/"+teamID+"/members/"+username),
+	)
+}
@@ -2,14 +2,19 @@ package sevenbridges
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"os"
 	"time"
 
 	"bytes"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	c "github.com/delicb/cliware"
 	"github.com/delicb/cliware-middlewares/body"
 	"github.com/delicb/cliware-middlewares/headers"
 	"github.com/delicb/cliware-middlewares/query"
@@ -18,6 +23,62 @@ import (
 	"github.com/delicb/gwc"
 )
 
+const (
+	// UnknownSize can be provided as size to UploadReader when total size of
+	// data is not known in advance (e.g. when uploading output of another
+	// process). Data is read and buffered in memory part by part, so at most
+	// maxUploadParts parts of PartSize bytes can be uploaded.
+	UnknownSize int64 = -1
+
+	// maxUploadParts is maximal number of parts in single multipart upload.
+	maxUploadParts = 10000
+
+	// NoRetries can be used as UploadInfo.Retries to disable retrying of
+	// failed parts.
+	NoRetries = -1
+
+	// uploadWorkers is maximal number of parts uploaded in parallel.
+	uploadWorkers = 8
+	// defaultPartRetries is number of times upload of single part is retried
+	// before whole upload fails.
+	defaultPartRetries = 3
+	// partRetryBackoff is time to wait before first retry of failed part.
+	// It is doubled for every subsequent retry.
+	partRetryBackoff = 200 * time.Millisecond
+	// abortTimeout is time given to server to abort failed upload.
+	abortTimeout = 30 * time.Second
+	// maxRenameAttempts is maximal number of names tried by ConflictRename.
+	maxRenameAttempts = 1000
+)
+
+// ErrUploadSkipped is returned together with existing file when upload has
+// been skipped because of conflict policy.
+var ErrUploadSkipped = errors.New("sevenbridges: upload skipped, file already exists")
+
+// ConflictPolicy defines what should be done when file with the same name
+// already exists in upload destination.
+type ConflictPolicy int
+
+const (
+	// ConflictFail leaves conflict resolution to the platform, which rejects
+	// upload of existing file. This is default policy.
+	ConflictFail ConflictPolicy = iota
+	// ConflictOverwrite overwrites existing file.
+	ConflictOverwrite
+	// ConflictSkip skips upload if file with the same name exists.
+	ConflictSkip
+	// ConflictSkipSameSize skips upload if file with the same name and size
+	// exists and overwrites it otherwise. Only size is compared, content
+	// (checksum) is not, so file with same size but different content is
+	// skipped too. If size of uploaded data is not known, it behaves like
+	// ConflictFail.
+	ConflictSkipSameSize
+	// ConflictRename uploads file under new name with numeric suffix added
+	// before extension (e.g. "sample_1.bam", "sample_2.bam"). Upload fails if
+	// free name is not found in maxRenameAttempts attempts.
+	ConflictRename
+)
+
 // UploadInitResponse holds information about upload that is in progress with
 // all necessary information.
 type UploadInitResponse struct {
@@ -45,35 +106,106 @@ type PartUploadInitResponse struct {
 // and various options.
 type UploadInfo struct {
 	// Path is path to file that should be uploaded on local filesystem.
+	// It is ignored when uploading from reader.
 	Path string
 	// Name is desired name of uploaded file. If not provided, same name
-	// as on local file system will be used.
+	// as on local file system will be used. It is required when uploading
+	// from reader.
 	Name string `json:"name"`
 	// Overwrite is flag marking if upload should be continued even if file
-	// with same name exists on platform.
+	// with same name exists on platform. It is same as using ConflictOverwrite.
 	Overwrite bool
+	// Conflict defines what should be done if file with same name already
+	// exists on platform. It is checked before upload is started.
+	Conflict ConflictPolicy
 	// Project is ID of project to which to upload file.
 	Project string `json:"project"`
+	// Parent is ID of folder to which to upload file. If provided, it takes
+	// precedence over Project.
+	Parent string `json:"parent"`
+	// Retries is number of times upload of a single part is retried before
+	// whole upload fails. If not provided (zero), 3 retries are made. Use
+	// NoRetries (or any negative number) to disable retries. Only server
+	// errors, rate limiting and network errors are retried.
+	Retries int
 
 	// there are private and will be populated by library
-	stat os.FileInfo
+	size int64
+	// partSlots, if not nil, limits number of parts uploaded at the same time
+	// across all uploads sharing it. Slot is taken by sending to channel.
+	partSlots chan struct{}
+}
+
+// PartError holds information about part of multipart upload that could not
+// be uploaded.
+type PartError struct {
+	Part int
+	Err  error
+}
+
+// UploadError is returned when one or more parts of multipart upload could
+// not be uploaded even after retries. Such upload is aborted on server.
+type UploadError struct {
+	UploadID string
+	Parts    []*PartError
+	// AbortError is error that occurred while aborting upload on server. If
+	// it is not nil, upload might still exist on server.
+	AbortError error
+}
+
+// Implementation of error interface
+func (ue *UploadError) Error() string {
+	failed := make([]string, 0, len(ue.Parts))
+	for _, p := range ue.Parts {
+		failed = append(failed, fmt.Sprintf("part %d: %s", p.Part, p.Err))
+	}
+	msg := fmt.Sprintf("sevenbridges: upload %s failed [%s]", ue.UploadID, strings.Join(failed, "; "))
+	if ue.AbortError != nil {
+		msg += fmt.Sprintf(", abort failed: %s", ue.AbortError)
+	}
+	return msg
 }
 
 type part struct {
-	ID        int
-	StartByte int64
-	EndByte   int64
-	ETag      string
-	Error     error
+	ID int
+	// Data is content of part read from stream. Parts of files are not
+	// buffered, but read from file when uploaded.
+	Data []byte
+	// file, offset and size define content of part of a file.
+	file   io.ReaderAt
+	offset int64
+	size   int64
+	ETag   string
+}
+
+// reader returns new reader of part content and its length. New reader is
+// returned on every call, so part can be uploaded again after failure.
+func (p *part) reader() (io.Reader, int64) {
+	if p.file != nil {
+		return io.NewSectionReader(p.file, p.offset, p.size), p.size
+	}
+	return bytes.NewReader(p.Data), int64(len(p.Data))
 }
 
 // MultipartUpload holds information about upload of a file to platform.
 type MultipartUpload struct {
-	Href      string `json:"href"`
-	UploadID  string `json:"upload_id"`
-	Project   string `json:"project"`
-	Name      string `json:"name"`
-	Initiated string `json:"initiated"`
+	Href      string    `json:"href"`
+	UploadID  string    `json:"upload_id"`
+	Project   string    `json:"project"`
+	Name      string    `json:"name"`
+	Initiated Timestamp `json:"initiated"`
+}
+
+// UploadListOptions specifies optional filters for listing ongoing uploads.
+type UploadListOptions struct {
+	ListOptions
+	// Project is ID of project whose uploads should be listed.
+	Project string `url:"project,omitempty"`
+	// OlderThan leaves out uploads initiated less than provided duration ago.
+	// Uploads without known initiation time are left out as well. Filtering
+	// is done on client side, so page might contain less items than
+	// requested.
+	OlderThan time.Duration `url:"-"`
 }
 
 type multipartUploadPage struct {
@@ -85,9 +217,29 @@ type multipartUploadPage struct {
 type UploadService interface {
 	// Upload uploads file to SevenBridges platform. Which files is uploaded
 	// and to which project can be defined in provided options.
-	Upload(ctx context.Context, info UploadInfo) error
-	// List returns all ongoing uploads.
-	List(ctx context.Context) ([]*MultipartUpload, *Response, error)
+	// Uploaded file is returned. If upload fails after it has been
+	// initialized, it is aborted on server. If upload is skipped because of
+	// conflict policy, existing file is returned together with
+	// ErrUploadSkipped.
+	Upload(ctx context.Context, info UploadInfo) (*File, error)
+	// UploadReader uploads data read from r to SevenBridges platform. Size
+	// is total number of bytes that will be read from r or UnknownSize if it
+	// is not known in advance. Name in provided info is required. Upload
+	// fails as soon as r turns out to have more than size bytes. If upload is skipped because of conflict policy, existing file is
+	// returned together with ErrUploadSkipped.
+	UploadReader(ctx context.Context, r io.Reader, size int64, info UploadInfo) (*File, error)
+	// UploadDir uploads content of local directory to project with provided
+	// ID, recreating its directory structure as folders on platform. Result
+	// of upload of each file is returned. Symbolic links to files are
+	// followed, other entries that are not regular files are reported with
+	// ErrNotRegularFile.
+	UploadDir(ctx context.Context, localDir, projectID string, opts *UploadDirOptions) ([]*UploadDirResult, error)
+	// List returns ongoing uploads (single page) that match provided options.
+	List(ctx context.Context, opt *UploadListOptions) ([]*MultipartUpload, *Response, error)
+	// AbortStale aborts all ongoing uploads initiated more than provided
+	// duration ago and returns uploads that were aborted. Uploads without
+	// known initiation time are never aborted. Duration must be positive.
+	AbortStale(ctx context.Context, olderThan time.Duration) ([]*MultipartUpload, error)
 	// About stops upload with provided ID. Note that this has nothing to do
 	// with current running process, this only aborts upload on server.
 	// User should be careful which upload it is safe to abort.
@@ -96,89 +248,266 @@ type UploadService interface {
 
 type uploadService struct {
 	*service
+	files FileService
 }
 
 func newUploadService(client gwc.Doer) UploadService {
-	return &uploadService{newService(client)}
+	return &uploadService{newService(client), newFileService(client)}
 }
 
 var _ UploadService = new(uploadService)
 
-func (u *uploadService) List(ctx context.Context) ([]*MultipartUpload, *Response, error) {
+func (u *uploadService) List(ctx context.Context, opt *UploadListOptions) ([]*MultipartUpload, *Response, error) {
 	var multipartUpload []*MultipartUpload
 	resp, err := u.Do(
 		ctx,
 		headers.Method("GET"),
-		url.AddPath("/upload/multiplart"),
+		url.AddPath("/upload/multipart"),
+		queryOptions(opt),
 		pageResponse(&multipartUpload),
 	)
-	return multipartUpload, resp, err
+	if err != nil || opt == nil || opt.OlderThan <= 0 {
+		return multipartUpload, resp, err
+	}
+	filtered := make([]*MultipartUpload, 0, len(multipartUpload))
+	for _, mu := range multipartUpload {
+		// age of upload without initiation time is unknown, so it must not be
+		// considered stale
+		if !mu.Initiated.IsZero() && time.Since(mu.Initiated.Time) > opt.OlderThan {
+			filtered = append(filtered, mu)
+		}
+	}
+	return filtered, resp, err
+}
+
+func (u *uploadService) AbortStale(ctx context.Context, olderThan time.Duration) ([]*MultipartUpload, error) {
+	// without positive duration every upload, including those in progress,
+	// would be considered stale
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("sevenbridges: age of stale uploads must be positive, got %s", olderThan)
+	}
+	// collect all stale uploads first, since aborting them while going
+	// through pages would shift page offsets
+	var stale []*MultipartUpload
+	opt := &UploadListOptions{OlderThan: olderThan}
+	for {
+		uploads, resp, err := u.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, mu := range uploads {
+			if !mu.Initiated.IsZero() {
+				stale = append(stale, mu)
+			}
+		}
+		if !resp.HasNextPage() {
+			break
+		}
+		opt.ListOptions = *resp.NextPage()
+	}
+
+	var (
+		aborted  []*MultipartUpload
+		firstErr error
+	)
+	for _, mu := range stale {
+		if _, err := u.Abort(ctx, mu.UploadID); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		aborted = append(aborted, mu)
+	}
+	return aborted, firstErr
 }
 
 func (u *uploadService) Abort(ctx context.Context, uploadID string) (*Response, error) {
 	return u.Do(
 		ctx,
 		headers.Method("DELETE"),
-		url.AddPath("upload/multipart/"+uploadID),
+		url.AddPath("/upload/multipart/:uploadID"),
+		url.Param("uploadID", uploadID),
 	)
 }
 
-func (u *uploadService) Upload(ctx context.Context, uploadInfo UploadInfo) error {
+func (u *uploadService) Upload(ctx context.Context, uploadInfo UploadInfo) (*File, error) {
+	f, err := os.Open(uploadInfo.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if uploadInfo.Name == "" {
+		uploadInfo.Name = filepath.Base(uploadInfo.Path)
+	}
+	// parts of a file are read directly from it when uploaded, instead of
+	// being buffered in memory
+	return u.upload(ctx, nil, f, stat.Size(), uploadInfo)
+}
+
+func (u *uploadService) UploadReader(ctx context.Context, r io.Reader, size int64, uploadInfo UploadInfo) (*File, error) {
+	return u.upload(ctx, r, nil, size, uploadInfo)
+}
+
+// upload uploads data from r or, if provided, file of provided size.
+func (u *uploadService) upload(ctx context.Context, r io.Reader, file io.ReaderAt, size int64, uploadInfo UploadInfo) (*File, error) {
 	/*
 		List of thinks to do for file upload:
-		- determine file size and local file name
-		- determine remote file name
-			- might be the same, might be different, provide override param
 		- initialize upload
 			- call to upload/multipart with file name, project ID, desired
-			  part size and total size
+			  part size and total size (if known)
 			- fetch response that has part size that has to be used, ID of upload,
 			  URL and some other stuff
-		- calculate offsets based on part size and total file size
+		- read data from reader part by part, each part is buffered in memory
+		  (parts of files are read from file when uploaded instead)
 		- for each part
 			- get URL where to upload PART
 			- upload part to fetched URL
 			- report that part upload is finished
 		- mark upload as completed
-
 	*/
+	if uploadInfo.Name == "" {
+		return nil, errors.New("sevenbridges: name of uploaded file is required")
+	}
+	uploadInfo.size = size
+	if uploadInfo.Overwrite {
+		uploadInfo.Conflict = ConflictOverwrite
+	}
 
-	stat, err := os.Stat(uploadInfo.Path)
+	existing, err := u.resolveConflict(ctx, &uploadInfo)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if existing != nil {
+		return existing, ErrUploadSkipped
 	}
 
-	uploadInfo.stat = stat
-	info, err := u.initUpload(ctx, uploadInfo, PartSize)
+	info, err := u.initUpload(ctx, uploadInfo, desiredPartSize(size))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	var wg sync.WaitGroup
-	totalParts := int(getNumberOfChunks(stat.Size(), info.PartSize))
-	parts := make(chan *part, intMin(totalParts, 8))
-	report := make(chan *part, intMin(totalParts, 8))
-	wg.Add(totalParts)
-
-	go getParts(stat.Size(), info.PartSize, parts)
-	for i := 0; i < intMin(totalParts, 8); i++ {
-		go u.processPart(ctx, info, uploadInfo, parts, report)
-	}
-	go func() {
-		for p := range report {
-			if p.Error != nil {
-				fmt.Println("Failed to upload part... ", p.Error)
-				p.Error = nil
-				parts <- p
-			} else {
-				wg.Done()
+	f, err := u.uploadParts(ctx, r, file, info, uploadInfo)
+	if err != nil {
+		// upload can not be finished, so do not leave it hanging on server
+		abortCtx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+		defer cancel()
+		if _, abortErr := u.Abort(abortCtx, info.UploadID); abortErr != nil {
+			if uploadErr, ok := err.(*UploadError); ok {
+				uploadErr.AbortError = abortErr
 			}
 		}
-	}()
+		return nil, err
+	}
+	return f, nil
+}
 
-	wg.Wait()
+// desiredPartSize returns part size requested from server for data of
+// provided size. Default part size is increased for large files, so they fit
+// in maximal number of parts.
+func desiredPartSize(size int64) int64 {
+	if size < 0 || getNumberOfChunks(size, PartSize) <= maxUploadParts {
+		return PartSize
+	}
+	return (size + maxUploadParts - 1) / maxUploadParts
+}
+
+// uploadParts reads data from r (or file, if provided), uploads it part by
+// part to initialized upload and finalizes upload when all parts are
+// uploaded. If any of the parts fails after all retries, remaining parts are
+// not uploaded and UploadError is returned.
+func (u *uploadService) uploadParts(ctx context.Context, r io.Reader, file io.ReaderAt, info *UploadInitResponse, uploadInfo UploadInfo) (*File, error) {
+	partSize := info.PartSize
+	if partSize <= 0 {
+		partSize = PartSize
+	}
+	if uploadInfo.size >= 0 && getNumberOfChunks(uploadInfo.size, partSize) > maxUploadParts {
+		return nil, fmt.Errorf("sevenbridges: %d bytes can not be uploaded in %d parts of %d bytes", uploadInfo.size, maxUploadParts, partSize)
+	}
+	workers := uploadWorkers
+	if !info.ParallelUploads {
+		workers = 1
+	} else if uploadInfo.size >= 0 {
+		workers = intMin(workers, int(getNumberOfChunks(uploadInfo.size, partSize)))
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	retries := uploadInfo.Retries
+	if retries == 0 {
+		retries = defaultPartRetries
+	} else if retries < 0 {
+		retries = 0
+	}
+
+	partsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		failedParts []*PartError
+	)
+	parts := make(chan *part, workers)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for p := range parts {
+				if partsCtx.Err() != nil {
+					continue
+				}
+				if uploadInfo.partSlots != nil {
+					select {
+					case uploadInfo.partSlots <- struct{}{}:
+					case <-partsCtx.Done():
+						continue
+					}
+				}
+				err := u.processPart(partsCtx, info, p, retries)
+				if uploadInfo.partSlots != nil {
+					<-uploadInfo.partSlots
+				}
+				// parts interrupted by cancellation did not really fail
+				if err == nil || partsCtx.Err() != nil {
+					continue
+				}
+				mu.Lock()
+				failedParts = append(failedParts, &PartError{Part: p.ID, Err: err})
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+
+	var (
+		read int64
+		err  error
+	)
+	if file != nil {
+		read, err = fileParts(partsCtx, file, uploadInfo.size, partSize, parts)
+	} else {
+		read, err = readParts(partsCtx, r, uploadInfo.size, partSize, parts)
+	}
 	close(parts)
-	close(report)
-	time.Sleep(1 * time.Second)
+	wg.Wait()
+
+	if len(failedParts) > 0 {
+		return nil, &UploadError{UploadID: info.UploadID, Parts: failedParts}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if uploadInfo.size >= 0 && read != uploadInfo.size {
+		return nil, fmt.Errorf("sevenbridges: expected to upload %d bytes, got %d", uploadInfo.size, read)
+	}
 	return u.uploadFinalize(ctx, info)
 }
 
@@ -189,78 +518,195 @@ func intMin(a, b int) int {
 	return b
 }
 
-func (u *uploadService) processPart(ctx context.Context, info *UploadInitResponse, uploadInfo UploadInfo, in <-chan *part, report chan<- *part) {
-	for p := range in {
-		fmt.Println("Got part: ", p.ID)
-		partInit, err := u.partUploadInit(ctx, info, p)
-		if err != nil {
-			p.Error = err
-			report <- p
+// processPart uploads single part, retrying failed attempts with exponential
+// backoff at most provided number of times. Errors that would not go away by
+// retrying (e.g. authentication or validation errors) are not retried.
+func (u *uploadService) processPart(ctx context.Context, info *UploadInitResponse, p *part, retries int) error {
+	backoff := partRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := u.uploadPart(ctx, info, p)
+		if err == nil || attempt >= retries || !temporary(err) {
+			return err
 		}
-		err = u.partUpload(ctx, uploadInfo, partInit, p)
-		if err != nil {
-			p.Error = err
-			report <- p
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// temporary returns true if request that failed with provided error might
+// succeed if retried. Server errors, rate limiting and network errors are
+// considered temporary, while other HTTP errors (4xx) are not.
+func temporary(err error) bool {
+	if httpErr, ok := err.(*HTTPError); ok {
+		code := httpErr.StatusCode
+		return code == 0 || code >= 500 || code == http.StatusTooManyRequests
+	}
+	return err != context.Canceled && err != context.DeadlineExceeded
+}
+
+// uploadPart does single attempt of uploading and reporting a part.
+func (u *uploadService) uploadPart(ctx context.Context, info *UploadInitResponse, p *part) error {
+	partInit, err := u.partUploadInit(ctx, info, p)
+	if err != nil {
+		return err
+	}
+	if err = u.partUpload(ctx, partInit, p); err != nil {
+		return err
+	}
+	return u.partReportUploaded(ctx, info, p)
+}
+
+// readParts reads data from r in chunks of partSize bytes and sends them to
+// out until r is exhausted. Total number of read bytes is returned. Error is
+// returned as soon as r turns out to have more than maxUploadParts parts or,
+// if size is known, more than size bytes.
+func readParts(ctx context.Context, r io.Reader, size, partSize int64, out chan<- *part) (int64, error) {
+	if size >= 0 {
+		// single byte over size is enough to know that data is too long
+		r = io.LimitReader(r, size+1)
+	}
+	var total int64
+	for id := 1; ; id++ {
+		buff := make([]byte, partSize)
+		n, err := io.ReadFull(r, buff)
+		if n > 0 && id > maxUploadParts {
+			return total, fmt.Errorf("sevenbridges: data exceeds maximal upload size of %d parts of %d bytes", maxUploadParts, partSize)
+		}
+		if size >= 0 && total+int64(n) > size {
+			return total, fmt.Errorf("sevenbridges: data exceeds declared size of %d bytes", size)
+		}
+		total += int64(n)
+		// empty file is still uploaded as single empty part
+		if n > 0 || id == 1 {
+			select {
+			case out <- &part{ID: id, Data: buff[:n]}:
+			case <-ctx.Done():
+				return total, ctx.Err()
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return total, nil
 		}
-		err = u.partReportUploaded(ctx, info, p)
 		if err != nil {
-			p.Error = err
-			report <- p
+			return total, err
 		}
-
-		report <- p
-		//wg.Done()
-		fmt.Println("Processed part: ", p.ID)
 	}
-	fmt.Println("Finishing process part worker")
 }
 
-func getParts(fileSize int64, partSize int64, out chan<- *part) {
-	start := int64(0)
-	end := int64(0)
-	count := 1
-	for end < fileSize {
-		end = start + partSize
-		if end > fileSize {
-			end = fileSize
+// fileParts sends parts of file of provided size to out. Parts hold only
+// their position in file, data is read when part is uploaded. Size of file is
+// returned.
+func fileParts(ctx context.Context, file io.ReaderAt, size, partSize int64, out chan<- *part) (int64, error) {
+	id := 1
+	for offset := int64(0); offset < size || id == 1; offset += partSize {
+		p := &part{ID: id, file: file, offset: offset, size: size - offset}
+		if p.size > partSize {
+			p.size = partSize
 		}
-		p := &part{
-			ID:        count,
-			StartByte: start,
-			EndByte:   end,
+		select {
+		case out <- p:
+		case <-ctx.Done():
+			return offset, ctx.Err()
 		}
-		out <- p
-		fmt.Println("Generated part: ", p.ID)
-		count++
-		start = end
+		id++
 	}
+	return size, nil
 }
 
-func (u *uploadService) initUpload(ctx context.Context, info UploadInfo, partSize int64) (*UploadInitResponse, error) {
-	var uploadName string
-	var overwrite string
+// resolveConflict checks if file with the same name exists in upload
+// destination and applies conflict policy from provided info. If upload
+// should be skipped, existing file is returned. Name and conflict policy in
+// provided info might be changed so upload is done without conflict.
+func (u *uploadService) resolveConflict(ctx context.Context, info *UploadInfo) (*File, error) {
+	if info.Conflict == ConflictFail || info.Conflict == ConflictOverwrite {
+		return nil, nil
+	}
+	existing, err := u.findFile(ctx, info.Project, info.Parent, info.Name)
+	if err != nil || existing == nil {
+		return nil, err
+	}
 
-	if info.Name == "" {
-		uploadName = filepath.Base(info.Path)
+	switch info.Conflict {
+	case ConflictSkip:
+		return existing, nil
+	case ConflictSkipSameSize:
+		if info.size < 0 {
+			info.Conflict = ConflictFail
+			return nil, nil
+		}
+		if existing.Size == info.size {
+			return existing, nil
+		}
+		info.Conflict = ConflictOverwrite
+	case ConflictRename:
+		ext := filepath.Ext(info.Name)
+		base := strings.TrimSuffix(info.Name, ext)
+		for i := 1; i <= maxRenameAttempts; i++ {
+			name := fmt.Sprintf("%s_%d%s", base, i, ext)
+			f, err := u.findFile(ctx, info.Project, info.Parent, name)
+			if err != nil {
+				return nil, err
+			}
+			if f == nil {
+				info.Name = name
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("sevenbridges: no free name for %s found in %d attempts", info.Name, maxRenameAttempts)
+	}
+	return nil, nil
+}
+
+// findFile returns file with provided name from project root or folder
+// with provided parent ID. If such file does not exist, nil is returned.
+func (u *uploadService) findFile(ctx context.Context, project, parent, name string) (*File, error) {
+	opt := &FileListOptions{Name: name}
+	if parent != "" {
+		opt.Parent = parent
 	} else {
-		uploadName = info.Name
+		opt.Project = project
+	}
+	files, _, err := u.files.Query(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if !f.IsFolder() && f.Name == name {
+			return f, nil
+		}
 	}
+	return nil, nil
+}
+
+func (u *uploadService) initUpload(ctx context.Context, info UploadInfo, partSize int64) (*UploadInitResponse, error) {
+	var overwrite string
 
-	if info.Overwrite {
+	if info.Conflict == ConflictOverwrite {
 		overwrite = "true"
 	} else {
 		overwrite = "false"
 	}
 
 	uploadInfo := map[string]interface{}{
-		"project":   info.Project,
-		"name":      uploadName,
+		"name":      info.Name,
 		"part_size": partSize,
-		"size":      info.stat.Size(),
+	}
+	if info.Parent != "" {
+		uploadInfo["parent"] = info.Parent
+	} else {
+		uploadInfo["project"] = info.Project
+	}
+	if info.size >= 0 {
+		uploadInfo["size"] = info.size
 	}
 	initResponse := new(UploadInitResponse)
 	_, err := u.Do(
 		ctx,
+		headers.Method("POST"),
 		url.AddPath("/upload/multipart"),
 		query.Add("overwrite", overwrite),
 		body.JSON(uploadInfo),
@@ -283,30 +729,34 @@ func (u *uploadService) partUploadInit(ctx context.Context, info *UploadInitResp
 	return m, err
 }
 
-func (u *uploadService) partUpload(ctx context.Context, uploadInfo UploadInfo, info *PartUploadInitResponse, p *part) error {
-	f, err := os.Open(uploadInfo.Path)
-	if err != nil {
-		return err
-	}
-	buff := make([]byte, p.EndByte-p.StartByte)
-	f.Seek(p.StartByte, os.SEEK_SET)
-	f.Read(buff)
-
+func (u *uploadService) partUpload(ctx context.Context, info *PartUploadInitResponse, p *part) error {
+	r, size := p.reader()
 	resp, err := u.Do(
 		ctx,
 		url.URL(info.URL),
 		headers.Method(info.Method),
-		body.Reader(bytes.NewReader(buff)),
+		body.Reader(r),
+		contentLength(size),
 	)
 	if err != nil {
 		return err
 	}
 	p.ETag = resp.Header.Get("ETag")
-
-	f.Close()
 	return nil
 }
 
+// contentLength returns middleware that sets length of request body, since
+// storage rejects part uploads without it.
+func contentLength(size int64) c.Middleware {
+	return c.RequestProcessor(func(req *http.Request) error {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+		return nil
+	})
+}
+
 func (u *uploadService) partReportUploaded(ctx context.Context, info *UploadInitResponse, p *part) error {
 	data := map[string]interface{}{
 		"part_number": p.ID,
@@ -316,29 +766,26 @@ func (u *uploadService) partReportUploaded(ctx context.Context, info *UploadInit
 			},
 		},
 	}
-	resp, err := u.Do(
+	_, err := u.Do(
 		ctx,
 		url.AddPath("/upload/multipart/:uploadID/part/"),
 		url.Param("uploadID", info.UploadID),
 		headers.Method("POST"),
 		body.JSON(data),
 	)
-	fmt.Println("Submit part status code:", resp.StatusCode)
 	return err
 }
 
-func (u *uploadService) uploadFinalize(ctx context.Context, info *UploadInitResponse) error {
-	resp, err := u.Do(
+func (u *uploadService) uploadFinalize(ctx context.Context, info *UploadInitResponse) (*File, error) {
+	f := new(File)
+	_, err := u.Do(
 		ctx,
 		headers.Method("POST"),
 		url.AddPath("/upload/multipart/:uploadID/complete"),
 		url.Param("uploadID", info.UploadID),
 		headers.Set("Content-Type", "application/json"),
 		headers.Set("Accept", "application/json"),
+		responsebody.JSON(f),
 	)
-	if err != nil {
-		return err
-	}
-	fmt.Println("Complete upload status code: ", resp.StatusCode)
-	return nil
+	return f, err
 }
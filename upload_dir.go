@@ -0,0 +1,308 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// defaultUploadDirConcurrency is number of parts uploaded at the same time
+// by UploadDir if not specified otherwise.
+const defaultUploadDirConcurrency = uploadWorkers
+
+// ErrNotRegularFile is reported by UploadDir for entries that can not be
+// uploaded, like symbolic links to directories or broken links.
+var ErrNotRegularFile = errors.New("sevenbridges: not a regular file")
+
+// FileAnnotation holds metadata and tags that should be applied to a file
+// after it has been uploaded.
+type FileAnnotation struct {
+	Metadata Metadata `json:"metadata"`
+	Tags     []string `json:"tags"`
+}
+
+// UploadDirOptions holds options for uploading whole directory.
+type UploadDirOptions struct {
+	// Include is list of glob patterns of files that should be uploaded.
+	// Patterns are matched against slash separated path relative to uploaded
+	// directory and patterns without slash are matched against file name
+	// as well. If empty, all files are included.
+	Include []string
+	// Exclude is list of glob patterns of files that should not be uploaded.
+	// Exclude patterns take precedence over Include patterns.
+	Exclude []string
+	// Parent is ID of folder to which directory content is uploaded. If not
+	// provided, content is uploaded to root of the project.
+	Parent string
+	// Conflict defines what should be done with files that already exist
+	// on platform.
+	Conflict ConflictPolicy
+	// Concurrency is maximal number of parts uploaded at the same time. Limit
+	// is shared by all files, so small files are uploaded in parallel while
+	// large file can use whole limit for its parts. If not provided, 8 parts
+	// are uploaded at the same time.
+	Concurrency int
+	// Annotate is called for every uploaded file with its relative path and
+	// returned metadata and tags are applied to uploaded file. Nil annotation
+	// means that nothing is applied. Files are uploaded concurrently, so
+	// Annotate is called from multiple goroutines at the same time.
+	Annotate func(relPath string) *FileAnnotation
+	// Manifest is path to JSON file that maps relative file paths to their
+	// annotations. Annotations from manifest are applied before annotations
+	// returned by Annotate.
+	Manifest string
+}
+
+// UploadDirResult holds outcome of uploading single file with UploadDir.
+type UploadDirResult struct {
+	// Path is path of local file relative to uploaded directory.
+	Path string
+	// File is uploaded file, nil if upload failed. If upload was skipped,
+	// it is existing file on platform.
+	File *File
+	// Skipped is flag marking that upload was skipped because of conflict
+	// policy.
+	Skipped bool
+	// Error is error that occurred during upload or annotation of the file.
+	// It is ErrNotRegularFile for entries that are not regular files or
+	// symbolic links to them.
+	Error error
+}
+
+func (u *uploadService) UploadDir(ctx context.Context, localDir, projectID string, opts *UploadDirOptions) ([]*UploadDirResult, error) {
+	if opts == nil {
+		opts = &UploadDirOptions{}
+	}
+	manifest := map[string]*FileAnnotation{}
+	if opts.Manifest != "" {
+		data, err := ioutil.ReadFile(opts.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, skipped, err := collectFiles(localDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := &folderResolver{
+		files:   u.files,
+		project: projectID,
+		root:    opts.Parent,
+		ids:     map[string]string{},
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultUploadDirConcurrency
+	}
+	// all files take slots for their parts from the same pool
+	partSlots := make(chan struct{}, concurrency)
+	results := make([]*UploadDirResult, len(paths))
+	infos := make([]UploadInfo, len(paths))
+	for i, p := range paths {
+		parent, err := folders.resolve(ctx, path.Dir(p))
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &UploadDirResult{Path: p}
+		infos[i] = UploadInfo{
+			Path:     filepath.Join(localDir, filepath.FromSlash(p)),
+			Name:     path.Base(p),
+			Conflict: opts.Conflict,
+			Project:  projectID,
+			Parent:   parent,
+
+			partSlots: partSlots,
+		}
+	}
+
+	// there is no point in uploading more files at the same time than there
+	// are part slots, since every file needs at least one
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				r := results[i]
+				r.File, r.Error = u.Upload(ctx, infos[i])
+				if r.Error == ErrUploadSkipped {
+					r.Skipped, r.Error = true, nil
+					continue
+				}
+				if r.Error != nil {
+					continue
+				}
+				r.Error = u.annotate(ctx, r.File, r.Path, manifest[r.Path], opts.Annotate)
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for _, p := range skipped {
+		results = append(results, &UploadDirResult{Path: p, Error: ErrNotRegularFile})
+	}
+	return results, nil
+}
+
+// annotate applies metadata and tags from manifest annotation and annotation
+// returned from callback to provided file.
+func (u *uploadService) annotate(ctx context.Context, f *File, rel string, fromManifest *FileAnnotation, callback func(string) *FileAnnotation) error {
+	annotations := []*FileAnnotation{fromManifest}
+	if callback != nil {
+		annotations = append(annotations, callback(rel))
+	}
+	metadata := Metadata{}
+	var tags []string
+	for _, a := range annotations {
+		if a == nil {
+			continue
+		}
+		for k, v := range a.Metadata {
+			metadata[k] = v
+		}
+		tags = append(tags, a.Tags...)
+	}
+	if len(metadata) > 0 {
+		m, _, err := u.files.SetMetadata(ctx, f.ID, metadata)
+		if err != nil {
+			return err
+		}
+		f.Metadata = m
+	}
+	if len(tags) > 0 {
+		t, _, err := u.files.SetTags(ctx, f.ID, tags)
+		if err != nil {
+			return err
+		}
+		f.Tags = t
+	}
+	return nil
+}
+
+// collectFiles walks through provided directory and returns slash separated
+// relative paths of all regular files that match include and exclude patterns
+// from provided options. Symbolic links to files are followed. Paths of
+// matching entries that are not regular files (e.g. links to directories)
+// are returned separately.
+func collectFiles(dir string, opts *UploadDirOptions) (paths, skipped []string, err error) {
+	manifest, _ := filepath.Abs(opts.Manifest)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		regular := info.Mode().IsRegular()
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Stat(p)
+			regular = err == nil && target.Mode().IsRegular()
+		}
+		if abs, _ := filepath.Abs(p); opts.Manifest != "" && abs == manifest {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(opts.Include) > 0 && !matchAny(opts.Include, rel) {
+			return nil
+		}
+		if matchAny(opts.Exclude, rel) {
+			return nil
+		}
+		if regular {
+			paths = append(paths, rel)
+		} else {
+			skipped = append(skipped, rel)
+		}
+		return nil
+	})
+	return paths, skipped, err
+}
+
+// matchAny returns true if slash separated relative path matches any of
+// provided glob patterns.
+func matchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// folderResolver finds or creates folders on platform that match local
+// directory structure and caches their IDs.
+type folderResolver struct {
+	files   FileService
+	project string
+	root    string
+	ids     map[string]string
+}
+
+// resolve returns ID of folder for provided slash separated path relative
+// to uploaded directory, creating all missing folders on the way. Empty ID
+// is returned for root of the project.
+func (fr *folderResolver) resolve(ctx context.Context, rel string) (string, error) {
+	if rel == "." || rel == "" {
+		return fr.root, nil
+	}
+	if id, ok := fr.ids[rel]; ok {
+		return id, nil
+	}
+	parent, err := fr.resolve(ctx, path.Dir(rel))
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(rel)
+
+	opt := &FileListOptions{Name: name}
+	if parent == "" {
+		opt.Project = fr.project
+	} else {
+		opt.Parent = parent
+	}
+	existing, _, err := fr.files.Query(ctx, opt)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range existing {
+		if f.IsFolder() && f.Name == name {
+			fr.ids[rel] = f.ID
+			return f.ID, nil
+		}
+	}
+
+	folder, _, err := fr.files.CreateFolder(ctx, FolderCreate{
+		Name:    name,
+		Project: opt.Project,
+		Parent:  opt.Parent,
+	})
+	if err != nil {
+		return "", err
+	}
+	fr.ids[rel] = folder.ID
+	return folder.ID, nil
+}
@@ -0,0 +1,200 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMatchAny(t *testing.T) {
+	for _, tc := range []struct {
+		patterns []string
+		rel      string
+		want     bool
+	}{
+		{[]string{"*.bam"}, "sample.bam", true},
+		{[]string{"*.bam"}, "dir/sample.bam", true},
+		{[]string{"dir/*.bam"}, "dir/sample.bam", true},
+		{[]string{"dir/*.bam"}, "other/sample.bam", false},
+		{[]string{"*.bam"}, "sample.bai", false},
+		{[]string{"*.bai", "*.bam"}, "dir/sample.bam", true},
+		{nil, "sample.bam", false},
+	} {
+		if got := matchAny(tc.patterns, tc.rel); got != tc.want {
+			t.Errorf("matchAny(%v, %q) = %t, want %t", tc.patterns, tc.rel, got, tc.want)
+		}
+	}
+}
+
+func TestCollectFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	outside, err := ioutil.TempDir("", "outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	for _, name := range []string{"a.bam", "a.bai", "sub/b.bam", "sub/c.txt", "manifest.json"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(p), 0755)
+		if err := ioutil.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	target := filepath.Join(outside, "linked.bam")
+	ioutil.WriteFile(target, []byte("linked"), 0644)
+	if err := os.Symlink(target, filepath.Join(dir, "linked.bam")); err != nil {
+		t.Skip("symbolic links not supported: ", err)
+	}
+	os.Symlink(outside, filepath.Join(dir, "linkdir.bam"))
+	os.Symlink(filepath.Join(outside, "missing"), filepath.Join(dir, "broken.bam"))
+
+	paths, skipped, err := collectFiles(dir, &UploadDirOptions{
+		Include:  []string{"*.bam", "*.json"},
+		Exclude:  []string{"sub/*.txt"},
+		Manifest: filepath.Join(dir, "manifest.json"),
+	})
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	sort.Strings(paths)
+	sort.Strings(skipped)
+	if want := []string{"a.bam", "linked.bam", "sub/b.bam"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected paths %v, got %v", want, paths)
+	}
+	if want := []string{"broken.bam", "linkdir.bam"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("Expected skipped %v, got %v", want, skipped)
+	}
+}
+
+// fakeFolderService keeps folders in memory and counts created folders.
+type fakeFolderService struct {
+	FileService
+	folders map[string]*File // key is parent (or project) + "/" + name
+	created int
+}
+
+func (fs *fakeFolderService) Query(ctx context.Context, opt *FileListOptions) ([]*File, *Response, error) {
+	if f, ok := fs.folders[opt.Project+opt.Parent+"/"+opt.Name]; ok {
+		return []*File{f}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+func (fs *fakeFolderService) CreateFolder(ctx context.Context, fc FolderCreate) (*File, *Response, error) {
+	fs.created++
+	f := &File{ID: fc.Name + "-id", Name: fc.Name, Type: FileTypeFolder}
+	fs.folders[fc.Project+fc.Parent+"/"+fc.Name] = f
+	return f, nil, nil
+}
+
+func TestFolderResolver(t *testing.T) {
+	files := &fakeFolderService{folders: map[string]*File{
+		"project/a": {ID: "existing-a", Name: "a", Type: FileTypeFolder},
+	}}
+	resolver := &folderResolver{files: files, project: "project", ids: map[string]string{}}
+
+	for _, tc := range []struct {
+		rel  string
+		want string
+	}{
+		{".", ""},
+		{"a", "existing-a"},
+		{"a/b", "b-id"},
+		{"a/b/c", "c-id"},
+		{"a/b", "b-id"},
+	} {
+		id, err := resolver.resolve(context.Background(), tc.rel)
+		if err != nil {
+			t.Fatalf("%s: got error: %s", tc.rel, err)
+		}
+		if id != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.rel, tc.want, id)
+		}
+	}
+	if files.created != 2 {
+		t.Errorf("Expected 2 created folders, got %d", files.created)
+	}
+	if _, ok := files.folders["existing-a/b"]; !ok {
+		t.Error("Folder b not created in existing folder a")
+	}
+}
+
+func TestUploadDirSharesPartConcurrency(t *testing.T) {
+	var (
+		mu              sync.Mutex
+		inFlight, maxIn int
+		uploadedParts   int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case r.Method == "GET" && path == "/files":
+			w.Write([]byte(`{"items": []}`))
+		case r.Method == "POST" && path == "/upload/multipart":
+			w.Write([]byte(`{"upload_id": "upload", "part_size": 4, "parallel_uploads": true}`))
+		case r.Method == "GET" && strings.HasPrefix(path, "/upload/multipart/upload/part/"):
+			json.NewEncoder(w).Encode(map[string]string{"method": "PUT", "url": "http://" + r.Host + "/storage"})
+		case r.Method == "PUT" && path == "/storage":
+			mu.Lock()
+			inFlight++
+			if inFlight > maxIn {
+				maxIn = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			uploadedParts++
+			mu.Unlock()
+		case r.Method == "POST" && path == "/upload/multipart/upload/complete":
+			w.Write([]byte(`{"id": "file"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "upload-dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		// 4 parts of 4 bytes each
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("0123456789abcdef"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uploads := newUploadService(newClient(server.URL, "token"))
+	results, err := uploads.UploadDir(context.Background(), dir, "project", &UploadDirOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			t.Errorf("%s: got error: %s", r.Path, r.Error)
+		}
+	}
+	if uploadedParts != 12 {
+		t.Errorf("Expected 12 uploaded parts, got %d", uploadedParts)
+	}
+	if maxIn > 2 {
+		t.Errorf("Expected at most 2 parts uploaded at the same time, got %d", maxIn)
+	}
+}
@@ -0,0 +1,67 @@
+package sevenbridges
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestReadPartsLimit(t *testing.T) {
+	parts := make(chan *part)
+	go func() {
+		for range parts {
+		}
+	}()
+	defer close(parts)
+
+	data := bytes.Repeat([]byte("x"), maxUploadParts+1)
+	read, err := readParts(context.Background(), bytes.NewReader(data), UnknownSize, 1, parts)
+	if err == nil {
+		t.Fatal("Expected error for data exceeding part limit")
+	}
+	if read != maxUploadParts {
+		t.Errorf("Expected to stop after %d bytes, read %d", maxUploadParts, read)
+	}
+
+	read, err = readParts(context.Background(), bytes.NewReader(data[:maxUploadParts]), UnknownSize, 1, parts)
+	if err != nil || read != maxUploadParts {
+		t.Errorf("Expected %d bytes without error, got %d, %v", maxUploadParts, read, err)
+	}
+}
+
+func TestReadPartsDeclaredSize(t *testing.T) {
+	parts := make(chan *part)
+	var sent int
+	done := make(chan struct{})
+	go func() {
+		for range parts {
+			sent++
+		}
+		close(done)
+	}()
+
+	data := bytes.Repeat([]byte("x"), 100)
+	read, err := readParts(context.Background(), bytes.NewReader(data), 10, 4, parts)
+	close(parts)
+	<-done
+	if err == nil {
+		t.Fatal("Expected error for data exceeding declared size")
+	}
+	// parts 1 and 2 are full, part 3 would go over declared size
+	if read != 8 || sent != 2 {
+		t.Errorf("Expected to stop after 2 parts and 8 bytes, got %d parts and %d bytes", sent, read)
+	}
+}
+
+func TestDesiredPartSize(t *testing.T) {
+	if s := desiredPartSize(UnknownSize); s != PartSize {
+		t.Errorf("Expected default part size for unknown size, got %d", s)
+	}
+	if s := desiredPartSize(maxUploadParts * PartSize); s != PartSize {
+		t.Errorf("Expected default part size for file that fits, got %d", s)
+	}
+	size := int64(maxUploadParts*PartSize + 1)
+	if s := desiredPartSize(size); getNumberOfChunks(size, s) > maxUploadParts {
+		t.Errorf("Part size %d results in too many parts", s)
+	}
+}
@@ -0,0 +1,417 @@
+package sevenbridges_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/delicb/sevenbridges-go"
+)
+
+// fakeMultipartServer imitates multipart upload endpoints of SevenBridges
+// API and storage to which parts are uploaded.
+type fakeMultipartServer struct {
+	*httptest.Server
+	partSize int64
+
+	mu       sync.Mutex
+	parts    map[int][]byte
+	reported map[int]string
+	failures map[int]int // number of times upload of part should fail
+	failCode int         // status code of failed part uploads
+	attempts map[int]int
+	aborted  bool
+	complete bool
+
+	existing  map[string]int64 // sizes of files that already exist, by name
+	initName  string           // name of file upload was initiated for
+	overwrite string           // overwrite parameter upload was initiated with
+}
+
+func newFakeMultipartServer(partSize int64) *fakeMultipartServer {
+	s := &fakeMultipartServer{
+		partSize: partSize,
+		parts:    map[int][]byte{},
+		reported: map[int]string{},
+		failures: map[int]int{},
+		attempts: map[int]int{},
+		existing: map[string]int64{},
+		failCode: http.StatusInternalServerError,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *fakeMultipartServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case r.Method == "GET" && path == "/files":
+		items := []interface{}{}
+		name := r.URL.Query().Get("name")
+		if size, ok := s.existing[name]; ok {
+			items = append(items, map[string]interface{}{
+				"id": "existing-" + name, "name": name, "size": size, "type": "file",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	case r.Method == "POST" && path == "/upload/multipart":
+		var init struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(r.Body).Decode(&init)
+		s.initName = init.Name
+		s.overwrite = r.URL.Query().Get("overwrite")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"upload_id":        "upload1",
+			"part_size":        s.partSize,
+			"parallel_uploads": true,
+		})
+	case r.Method == "GET" && strings.HasPrefix(path, "/upload/multipart/upload1/part/"):
+		id := strings.TrimPrefix(path, "/upload/multipart/upload1/part/")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"method": "PUT",
+			"url":    s.URL + "/storage/" + id,
+		})
+	case r.Method == "PUT" && strings.HasPrefix(path, "/storage/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(path, "/storage/"))
+		s.attempts[id]++
+		if s.failures[id] > 0 {
+			s.failures[id]--
+			w.WriteHeader(s.failCode)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		s.parts[id] = data
+		w.Header().Set("ETag", fmt.Sprintf("etag-%d", id))
+	case r.Method == "POST" && path == "/upload/multipart/upload1/part/":
+		var report struct {
+			PartNumber int `json:"part_number"`
+			Response   struct {
+				Headers map[string]string `json:"headers"`
+			} `json:"response"`
+		}
+		json.NewDecoder(r.Body).Decode(&report)
+		s.reported[report.PartNumber] = report.Response.Headers["ETag"]
+	case r.Method == "POST" && path == "/upload/multipart/upload1/complete":
+		s.complete = true
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "file1", "name": "data.txt"})
+	case r.Method == "DELETE" && path == "/upload/multipart/upload1":
+		s.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// data returns all uploaded parts joined together.
+func (s *fakeMultipartServer) data() []byte {
+	var buff bytes.Buffer
+	for i := 1; i <= len(s.parts); i++ {
+		buff.Write(s.parts[i])
+	}
+	return buff.Bytes()
+}
+
+func TestUploadReader(t *testing.T) {
+	content := []byte("some content that is split into multiple parts")
+	for _, size := range []int64{int64(len(content)), sevenbridges.UnknownSize} {
+		server := newFakeMultipartServer(8)
+		client := sevenbridges.New(server.URL, "token")
+
+		f, err := client.Upload.UploadReader(
+			context.Background(),
+			bytes.NewReader(content),
+			size,
+			sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project"},
+		)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Got error for size %d: %s", size, err)
+		}
+		if f.ID != "file1" {
+			t.Errorf("Expected file1, got %s", f.ID)
+		}
+		if !bytes.Equal(server.data(), content) {
+			t.Errorf("Uploaded data differs: %q", server.data())
+		}
+		if len(server.reported) != 6 || server.reported[6] != "etag-6" {
+			t.Errorf("Parts not reported properly: %v", server.reported)
+		}
+		if !server.complete || server.aborted {
+			t.Errorf("Upload not finished properly, complete: %t, aborted: %t", server.complete, server.aborted)
+		}
+	}
+}
+
+func TestUploadReaderRetriesPart(t *testing.T) {
+	server := newFakeMultipartServer(8)
+	defer server.Close()
+	server.failures[2] = 2
+	client := sevenbridges.New(server.URL, "token")
+
+	content := []byte("retried content")
+	_, err := client.Upload.UploadReader(
+		context.Background(),
+		bytes.NewReader(content),
+		int64(len(content)),
+		sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project"},
+	)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if server.attempts[2] != 3 {
+		t.Errorf("Expected 3 attempts for part 2, got %d", server.attempts[2])
+	}
+	if !bytes.Equal(server.data(), content) {
+		t.Errorf("Uploaded data differs: %q", server.data())
+	}
+}
+
+func TestUploadReaderAbortsOnFailure(t *testing.T) {
+	server := newFakeMultipartServer(8)
+	defer server.Close()
+	server.failures[1] = 100
+	client := sevenbridges.New(server.URL, "token")
+
+	content := []byte("content that will never be uploaded")
+	_, err := client.Upload.UploadReader(
+		context.Background(),
+		bytes.NewReader(content),
+		int64(len(content)),
+		sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project", Retries: 1},
+	)
+	uploadErr, ok := err.(*sevenbridges.UploadError)
+	if !ok {
+		t.Fatalf("Expected UploadError, got %#v", err)
+	}
+	if len(uploadErr.Parts) != 1 || uploadErr.Parts[0].Part != 1 {
+		t.Errorf("Expected only part 1 to fail, got %s", uploadErr)
+	}
+	if server.attempts[1] != 2 {
+		t.Errorf("Expected 2 attempts for part 1, got %d", server.attempts[1])
+	}
+	if !server.aborted || server.complete {
+		t.Errorf("Upload not aborted, complete: %t, aborted: %t", server.complete, server.aborted)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	content := []byte("file content that is read part by part")
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data.txt")
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := newFakeMultipartServer(8)
+	defer server.Close()
+	server.failures[3] = 1
+	client := sevenbridges.New(server.URL, "token")
+
+	f, err := client.Upload.Upload(context.Background(), sevenbridges.UploadInfo{Path: path, Project: "user/project"})
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if f.ID != "file1" {
+		t.Errorf("Expected file1, got %s", f.ID)
+	}
+	// retried part has to be read from file again
+	if !bytes.Equal(server.data(), content) {
+		t.Errorf("Uploaded data differs: %q", server.data())
+	}
+}
+
+func TestUploadConflictPolicies(t *testing.T) {
+	content := []byte("content")
+	for _, tc := range []struct {
+		name      string
+		policy    sevenbridges.ConflictPolicy
+		existing  map[string]int64
+		skipped   bool
+		initName  string
+		overwrite string
+	}{
+		{"fail", sevenbridges.ConflictFail, map[string]int64{"data.txt": 7}, false, "data.txt", "false"},
+		{"overwrite", sevenbridges.ConflictOverwrite, map[string]int64{"data.txt": 7}, false, "data.txt", "true"},
+		{"skip", sevenbridges.ConflictSkip, map[string]int64{"data.txt": 1}, true, "", ""},
+		{"skip missing", sevenbridges.ConflictSkip, nil, false, "data.txt", "false"},
+		{"skip same size", sevenbridges.ConflictSkipSameSize, map[string]int64{"data.txt": 7}, true, "", ""},
+		{"skip different size", sevenbridges.ConflictSkipSameSize, map[string]int64{"data.txt": 1}, false, "data.txt", "true"},
+		{"rename", sevenbridges.ConflictRename, map[string]int64{"data.txt": 7, "data_1.txt": 7}, false, "data_2.txt", "false"},
+	} {
+		server := newFakeMultipartServer(8)
+		for name, size := range tc.existing {
+			server.existing[name] = size
+		}
+		client := sevenbridges.New(server.URL, "token")
+
+		f, err := client.Upload.UploadReader(
+			context.Background(),
+			bytes.NewReader(content),
+			int64(len(content)),
+			sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project", Conflict: tc.policy},
+		)
+		server.Close()
+		if tc.skipped {
+			if err != sevenbridges.ErrUploadSkipped {
+				t.Errorf("%s: expected ErrUploadSkipped, got %v", tc.name, err)
+			} else if f == nil || f.ID != "existing-data.txt" {
+				t.Errorf("%s: expected existing file, got %+v", tc.name, f)
+			}
+		} else if err != nil {
+			t.Errorf("%s: got error: %s", tc.name, err)
+		}
+		if server.initName != tc.initName || server.overwrite != tc.overwrite {
+			t.Errorf("%s: expected upload of %q with overwrite=%q, got %q with overwrite=%q",
+				tc.name, tc.initName, tc.overwrite, server.initName, server.overwrite)
+		}
+	}
+}
+
+func TestUploadConflictRenameLimit(t *testing.T) {
+	server := newFakeMultipartServer(8)
+	defer server.Close()
+	server.existing["data.txt"] = 1
+	for i := 1; i <= 1000; i++ {
+		server.existing[fmt.Sprintf("data_%d.txt", i)] = 1
+	}
+	client := sevenbridges.New(server.URL, "token")
+
+	_, err := client.Upload.UploadReader(
+		context.Background(),
+		strings.NewReader("content"),
+		7,
+		sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project", Conflict: sevenbridges.ConflictRename},
+	)
+	if err == nil {
+		t.Fatal("Expected error when no free name is found")
+	}
+	if server.initName != "" {
+		t.Errorf("Upload should not be initiated, got %q", server.initName)
+	}
+}
+
+func TestUploadReaderRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		failCode int
+		retries  int
+		attempts int
+	}{
+		{"server error", http.StatusInternalServerError, 0, 4},
+		{"rate limited", http.StatusTooManyRequests, 1, 2},
+		{"forbidden", http.StatusForbidden, 0, 1},
+		{"no retries", http.StatusInternalServerError, sevenbridges.NoRetries, 1},
+	} {
+		server := newFakeMultipartServer(8)
+		server.failures[1] = 100
+		server.failCode = tc.failCode
+		client := sevenbridges.New(server.URL, "token")
+
+		_, err := client.Upload.UploadReader(
+			context.Background(),
+			strings.NewReader("content"),
+			7,
+			sevenbridges.UploadInfo{Name: "data.txt", Project: "user/project", Retries: tc.retries},
+		)
+		server.Close()
+		if _, ok := err.(*sevenbridges.UploadError); !ok {
+			t.Errorf("%s: expected UploadError, got %#v", tc.name, err)
+		}
+		if server.attempts[1] != tc.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", tc.name, tc.attempts, server.attempts[1])
+		}
+	}
+}
+
+// fakeUploadsServer lists ongoing uploads two per page and records aborted
+// uploads.
+func fakeUploadsServer(uploads []map[string]interface{}, aborted *[]string) *httptest.Server {
+	var mu sync.Mutex
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == "DELETE" {
+			*aborted = append(*aborted, strings.TrimPrefix(r.URL.Path, "/upload/multipart/"))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end < len(uploads) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/upload/multipart?offset=%d&limit=2>; rel="next"`, server.URL, end))
+		} else {
+			end = len(uploads)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": uploads[offset:end]})
+	}))
+	return server
+}
+
+func TestAbortStale(t *testing.T) {
+	now := time.Now()
+	uploads := []map[string]interface{}{
+		{"upload_id": "old1", "initiated": now.Add(-48 * time.Hour).Unix()},
+		{"upload_id": "new1", "initiated": now.Add(-time.Minute).Unix()},
+		{"upload_id": "unknown1"},
+		{"upload_id": "old2", "initiated": now.Add(-25 * time.Hour).Format(time.RFC3339)},
+		{"upload_id": "unknown2", "initiated": nil},
+	}
+	var aborted []string
+	server := fakeUploadsServer(uploads, &aborted)
+	defer server.Close()
+	client := sevenbridges.New(server.URL, "token")
+
+	page, _, err := client.Upload.List(context.Background(), &sevenbridges.UploadListOptions{OlderThan: 24 * time.Hour})
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	if len(page) != 1 || page[0].UploadID != "old1" {
+		t.Errorf("Expected only old1 on first page, got %v", page)
+	}
+
+	for _, olderThan := range []time.Duration{0, -time.Hour} {
+		if _, err := client.Upload.AbortStale(context.Background(), olderThan); err == nil {
+			t.Errorf("Expected error for non-positive duration %s", olderThan)
+		}
+	}
+	if len(aborted) != 0 {
+		t.Fatalf("Expected no aborted uploads for invalid duration, got %v", aborted)
+	}
+
+	stale, err := client.Upload.AbortStale(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatal("Got error: ", err)
+	}
+	var ids []string
+	for _, mu := range stale {
+		ids = append(ids, mu.UploadID)
+	}
+	expected := []string{"old1", "old2"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected stale uploads %v, got %v", expected, ids)
+	}
+	if !reflect.DeepEqual(aborted, expected) {
+		t.Errorf("Expected aborted uploads %v, got %v", expected, aborted)
+	}
+}
@@ -0,0 +1,340 @@
+package sevenbridges
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	nurl "net/url"
+	"time"
+
+	c "github.com/delicb/cliware"
+	"github.com/delicb/cliware-middlewares/body"
+	"github.com/delicb/cliware-middlewares/headers"
+	"github.com/delicb/cliware-middlewares/responsebody"
+	"github.com/delicb/cliware-middlewares/url"
+	"github.com/delicb/gwc"
+)
+
+const (
+	// VolumeS3 is type of volume backed by Amazon S3 bucket.
+	VolumeS3 = "s3"
+	// VolumeGCS is type of volume backed by Google Cloud Storage bucket.
+	VolumeGCS = "gcs"
+	// VolumeAzure is type of volume backed by Azure Blob Storage container.
+	VolumeAzure = "azure"
+
+	// VolumeReadOnly is access mode of volume that can only be read.
+	VolumeReadOnly = "RO"
+	// VolumeReadWrite is access mode of volume that can be read and written.
+	VolumeReadWrite = "RW"
+)
+
+// Volume holds information about cloud storage volume attached to
+// SevenBridges platform.
+type Volume struct {
+	Href        string         `json:"href"`
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	AccessMode  string         `json:"access_mode"`
+	Active      bool           `json:"active"`
+	Service     *VolumeBackend `json:"service"`
+	CreatedOn   time.Time      `json:"created_on"`
+	ModifiedOn  time.Time      `json:"modified_on"`
+}
+
+// VolumeBackend holds information about cloud storage that backs a volume.
+// Credentials are never returned by server.
+type VolumeBackend struct {
+	Type           string                 `json:"type"`
+	Bucket         string                 `json:"bucket,omitempty"`
+	StorageAccount string                 `json:"storage_account,omitempty"`
+	Container      string                 `json:"container,omitempty"`
+	Prefix         string                 `json:"prefix,omitempty"`
+	Endpoint       string                 `json:"endpoint,omitempty"`
+	Credentials    map[string]string      `json:"credentials,omitempty"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+}
+
+// S3Backend returns backend for volume on Amazon S3 bucket accessed with
+// provided access keys.
+func S3Backend(bucket, accessKeyID, secretAccessKey string) *VolumeBackend {
+	return &VolumeBackend{
+		Type:   VolumeS3,
+		Bucket: bucket,
+		Credentials: map[string]string{
+			"access_key_id":     accessKeyID,
+			"secret_access_key": secretAccessKey,
+		},
+	}
+}
+
+// GCSBackend returns backend for volume on Google Cloud Storage bucket
+// accessed with provided service account.
+func GCSBackend(bucket, clientEmail, privateKey string) *VolumeBackend {
+	return &VolumeBackend{
+		Type:   VolumeGCS,
+		Bucket: bucket,
+		Credentials: map[string]string{
+			"client_email": clientEmail,
+			"private_key":  privateKey,
+		},
+	}
+}
+
+// AzureBackend returns backend for volume on Azure Blob Storage container
+// accessed with provided service principal.
+func AzureBackend(storageAccount, container, tenantID, clientID, clientSecret string) *VolumeBackend {
+	return &VolumeBackend{
+		Type:           VolumeAzure,
+		StorageAccount: storageAccount,
+		Container:      container,
+		Credentials: map[string]string{
+			"tenant_id":     tenantID,
+			"client_id":     clientID,
+			"client_secret": clientSecret,
+		},
+	}
+}
+
+// VolumeCreate is structure that defines body required for creating new
+// volume.
+type VolumeCreate struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	AccessMode  string         `json:"access_mode,omitempty"`
+	Service     *VolumeBackend `json:"service"`
+}
+
+// VolumeModify is structure that defines body for modifying volume. Only
+// provided fields are modified.
+type VolumeModify struct {
+	Description string         `json:"description,omitempty"`
+	AccessMode  string         `json:"access_mode,omitempty"`
+	Service     *VolumeBackend `json:"service,omitempty"`
+}
+
+// VolumeMember holds information about member of a volume and its permissions.
+type VolumeMember struct {
+	Href        *string            `json:"href"`
+	Username    *string            `json:"username"`
+	Type        *string            `json:"type"`
+	Permissions *VolumePermissions `json:"permissions"`
+}
+
+// VolumePermissions holds set of permissions of a single member on volume.
+type VolumePermissions struct {
+	Read  *bool `json:"read"`
+	Copy  *bool `json:"copy"`
+	Write *bool `json:"write"`
+	Admin *bool `json:"admin"`
+}
+
+// VolumeObject is single object (file) on volume.
+type VolumeObject struct {
+	Href     string   `json:"href"`
+	Location string   `json:"location"`
+	Type     string   `json:"type"`
+	Volume   string   `json:"volume"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// VolumePrefix is common prefix (directory) of objects on volume.
+type VolumePrefix struct {
+	Href   string `json:"href"`
+	Prefix string `json:"prefix"`
+	Volume string `json:"volume"`
+}
+
+// VolumeBrowseOptions specifies optional parameters for browsing volume
+// content.
+type VolumeBrowseOptions struct {
+	// Prefix limits listing to objects whose location starts with prefix.
+	Prefix string `url:"prefix,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	// ContinuationToken is token from previous listing used to get next
+	// page of results.
+	ContinuationToken string `url:"continuation_token,omitempty"`
+}
+
+// VolumeListing holds single page of volume content.
+type VolumeListing struct {
+	Objects  []*VolumeObject `json:"items"`
+	Prefixes []*VolumePrefix `json:"prefixes"`
+	// ContinuationToken should be used to fetch next page of content. It is
+	// empty if there are no more pages.
+	ContinuationToken string `json:"-"`
+}
+
+// VolumeService is interface that defines volume related operations available
+// on SevenBridges platform.
+type VolumeService interface {
+	// List returns volumes (single page) available to current user.
+	List(ctx context.Context, opt *ListOptions) ([]*Volume, *Response, error)
+	// ByID returns volume with provided ID.
+	ByID(ctx context.Context, volumeID string) (*Volume, *Response, error)
+	// Create creates new volume.
+	Create(ctx context.Context, vc VolumeCreate) (*Volume, *Response, error)
+	// Modify edits volume with provided ID.
+	Modify(ctx context.Context, volumeID string, vm VolumeModify) (*Volume, *Response, error)
+	// Delete removes volume with provided ID. Content of cloud storage is
+	// not affected.
+	Delete(ctx context.Context, volumeID string) (*Response, error)
+	// Members returns members (single page) of volume with provided ID.
+	Members(ctx context.Context, volumeID string, opt *ListOptions) ([]*VolumeMember, *Response, error)
+	// GetMember returns member with provided username of volume with
+	// provided ID.
+	GetMember(ctx context.Context, volumeID, username string) (*VolumeMember, *Response, error)
+	// Browse returns single page of content of volume with provided ID.
+	Browse(ctx context.Context, volumeID string, opt *VolumeBrowseOptions) (*VolumeListing, *Response, error)
+}
+
+type volumeService struct {
+	*service
+}
+
+func newVolumeService(client gwc.Doer) VolumeService {
+	service := newService(client)
+	service.Use(url.AddPath("/storage/volumes"))
+	return &volumeService{service}
+}
+
+// just make sure at compile time that volumeService implements VolumeService
+var _ VolumeService = new(volumeService)
+
+func (vs *volumeService) List(ctx context.Context, opt *ListOptions) ([]*Volume, *Response, error) {
+	var v []*Volume
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("GET"),
+		listOptions(opt),
+		pageResponse(&v),
+	)
+	return v, resp, err
+}
+
+func (vs *volumeService) ByID(ctx context.Context, volumeID string) (*Volume, *Response, error) {
+	v := new(Volume)
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+volumeID),
+		responsebody.JSON(v),
+	)
+	return v, resp, err
+}
+
+func (vs *volumeService) Create(ctx context.Context, vc VolumeCreate) (*Volume, *Response, error) {
+	v := new(Volume)
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("POST"),
+		body.JSON(vc),
+		responsebody.JSON(v),
+	)
+	return v, resp, err
+}
+
+func (vs *volumeService) Modify(ctx context.Context, volumeID string, vm VolumeModify) (*Volume, *Response, error) {
+	v := new(Volume)
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("PATCH"),
+		url.AddPath("/"+volumeID),
+		body.JSON(vm),
+		responsebody.JSON(v),
+	)
+	return v, resp, err
+}
+
+func (vs *volumeService) Delete(ctx context.Context, volumeID string) (*Response, error) {
+	return vs.Do(
+		ctx,
+		headers.Method("DELETE"),
+		url.AddPath("/"+volumeID),
+	)
+}
+
+func (vs *volumeService) Members(ctx context.Context, volumeID string, opt *ListOptions) ([]*VolumeMember, *Response, error) {
+	var m []*VolumeMember
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+volumeID+"/members"),
+		listOptions(opt),
+		pageResponse(&m),
+	)
+	return m, resp, err
+}
+
+func (vs *volumeService) GetMember(ctx context.Context, volumeID, username string) (*VolumeMember, *Response, error) {
+	m := new(VolumeMember)
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+volumeID+"/members/"+username),
+		responsebody.JSON(m),
+	)
+	return m, resp, err
+}
+
+func (vs *volumeService) Browse(ctx context.Context, volumeID string, opt *VolumeBrowseOptions) (*VolumeListing, *Response, error) {
+	l := new(VolumeListing)
+	resp, err := vs.Do(
+		ctx,
+		headers.Method("GET"),
+		url.AddPath("/"+volumeID+"/list"),
+		queryOptions(opt),
+		volumeListingResponse(l),
+	)
+	if err != nil {
+		return l, resp, err
+	}
+	// continuation token might be sent in Link header as well
+	if next, ok := resp.Links["next"]; ok && l.ContinuationToken == "" {
+		l.ContinuationToken = continuationToken(next.Href)
+	}
+	return l, resp, err
+}
+
+// volumeListingResponse is middleware that deserializes volume listing and
+// extracts continuation token from its links.
+func volumeListingResponse(l *VolumeListing) c.Middleware {
+	return c.ResponseProcessor(func(resp *http.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		rawData, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(rawData, l); err != nil {
+			return err
+		}
+		var links struct {
+			Links []map[string]string `json:"links"`
+		}
+		// links are optional, so it is not an error if they are not present
+		json.Unmarshal(rawData, &links)
+		for _, link := range links.Links {
+			if link["rel"] == "next" && link["href"] != "" {
+				l.ContinuationToken = continuationToken(link["href"])
+			} else if next, ok := link["next"]; ok {
+				l.ContinuationToken = continuationToken(next)
+			}
+		}
+		return nil
+	})
+}
+
+// continuationToken returns value of "continuation_token" query parameter
+// from provided URL.
+func continuationToken(href string) string {
+	u, err := nurl.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("continuation_token")
+}